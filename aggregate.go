@@ -0,0 +1,169 @@
+package neo4jorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Count 统计满足条件的结果数量，遍历查询统计去重后的终点节点
+func (m *Model) Count() (int64, error) {
+	defer m.cleanQuery()
+	return m.count()
+}
+
+// Exists 是否存在满足条件的结果
+func (m *Model) Exists() (bool, error) {
+	defer m.cleanQuery()
+	if m.err != nil {
+		return false, m.err
+	}
+
+	returnClause := "n"
+	if len(m.hops) > 0 {
+		returnClause = m.traverseVar()
+	}
+	records, err := m.runAggregate(m.buildMatch() + " RETURN " + returnClause + " LIMIT 1")
+	if err != nil {
+		return false, err
+	}
+	return len(records) > 0, nil
+}
+
+// Sum 求和，结果按字段的类型返回，如整数字段返回整数，不会损失精度。
+// 没有满足条件的结果时为字段类型的零值
+func (m *Model) Sum(field string) (interface{}, error) {
+	return m.aggregateValue("sum", field)
+}
+
+// Avg 求平均值，没有满足条件的结果时为0
+func (m *Model) Avg(field string) (float64, error) {
+	return m.aggregateFloat("avg", field)
+}
+
+// Min 求最小值，结果按字段的类型返回，因此也可以求字符串或 time.Time 等字段的最小值。
+// 没有满足条件的结果时为字段类型的零值
+func (m *Model) Min(field string) (interface{}, error) {
+	return m.aggregateValue("min", field)
+}
+
+// Max 求最大值，规则同 Min
+func (m *Model) Max(field string) (interface{}, error) {
+	return m.aggregateValue("max", field)
+}
+
+// Distinct 查询字段去重后的值，out为切片指针，元素按字段相同的规则转换类型。
+// 数量限制同样生效，去重后只能按该字段排序
+func (m *Model) Distinct(field string, out interface{}) error {
+	defer m.cleanQuery()
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.Elem().Kind() != reflect.Slice {
+		return errors.New("results must be a pointer to a slice")
+	}
+	prop, err := m.aggregateField(field)
+	if err != nil {
+		return err
+	}
+	for _, item := range m.orderBy {
+		expr, _ := splitDirection(item)
+		if p, err := m.propertyName(expr); err != nil || p != prop {
+			return fmt.Errorf("Distinct: can not order by %q, only by the distinct field %s", item, field)
+		}
+	}
+
+	// 以属性名作为列名返回，排序时引用该列
+	m.selects = []string{field}
+	records, err := m.runAggregate(m.buildQueryReturning("DISTINCT " + strings.Join(m.projection(), ", ")))
+	if err != nil {
+		return err
+	}
+	sliceVal := outVal.Elem()
+	for _, rec := range records {
+		elem := reflect.New(sliceVal.Type().Elem()).Elem()
+		if err := assignValue(elem, rec.values[0], field); err != nil {
+			return err
+		}
+		sliceVal.Set(reflect.Append(sliceVal, elem))
+	}
+	return nil
+}
+
+// aggregateValue 执行 fn(n.<属性名>) 聚合，结果按字段相同的规则转换为字段的类型
+func (m *Model) aggregateValue(fn string, field string) (interface{}, error) {
+	defer m.cleanQuery()
+	prop, err := m.aggregateField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := m.runAggregate(m.buildMatch() + fmt.Sprintf(" RETURN %s(n.%s)", fn, prop))
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if len(records) > 0 {
+		value = records[0].values[0]
+	}
+	result := reflect.New(m.propertyType(prop)).Elem()
+	if err := assignValue(result, value, field); err != nil {
+		return nil, err
+	}
+	return result.Interface(), nil
+}
+
+// aggregateFloat 执行 fn(n.<属性名>) 聚合并转换为float64
+func (m *Model) aggregateFloat(fn string, field string) (float64, error) {
+	defer m.cleanQuery()
+	prop, err := m.aggregateField(field)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := m.runAggregate(m.buildMatch() + fmt.Sprintf(" RETURN %s(n.%s)", fn, prop))
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 || records[0].values[0] == nil {
+		return 0, nil
+	}
+	v, ok := convertToFloat(reflect.ValueOf(records[0].values[0]))
+	if !ok {
+		return 0, fmt.Errorf("%s(%s) returned %T", fn, field, records[0].values[0])
+	}
+	return v, nil
+}
+
+// aggregateField 解析聚合的字段，遍历查询的终点没有确定的模型，不支持按字段聚合
+func (m *Model) aggregateField(field string) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	if len(m.hops) > 0 {
+		return "", errors.New("field aggregates are not supported on traverse queries")
+	}
+	return m.propertyName(field)
+}
+
+// propertyType 返回属性对应字段的类型
+func (m *Model) propertyType(prop string) reflect.Type {
+	for name, p := range m.fieldMap {
+		if p == prop {
+			if field, ok := m.modelType.FieldByName(name); ok {
+				return field.Type
+			}
+		}
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+// runAggregate 执行聚合查询
+func (m *Model) runAggregate(query string) ([]*record, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.debug {
+		fmt.Printf("Executing Aggregate:\n%s\nWith params: %+v\n", query, m.params)
+	}
+	return m.query(query, m.params)
+}
@@ -0,0 +1,86 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type aggTestItem struct {
+	SKU   string `neo4j:"name=sku,primary,label=AggItem"`
+	Stock int64  `neo4j:"name=stock"`
+}
+
+func TestAggregates(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		switch {
+		case strings.Contains(query, "count(n)"):
+			return []*record{{values: []interface{}{int64(3)}}}
+		case strings.Contains(query, "avg(n.price)"):
+			return []*record{{values: []interface{}{float64(2.5)}}}
+		case strings.Contains(query, "max(n.price)"):
+			return []*record{{values: []interface{}{int64(4)}}}
+		case strings.Contains(query, "min(n.product_name)"):
+			return []*record{{values: []interface{}{"apple"}}}
+		case strings.Contains(query, "sum(n.stock)"):
+			return []*record{{values: []interface{}{int64(1<<62 + 1)}}}
+		case strings.Contains(query, "sum(n.price)"):
+			return []*record{{values: []interface{}{nil}}}
+		case strings.Contains(query, "DISTINCT n.product_name AS product_name"):
+			return []*record{{values: []interface{}{"a"}}, {values: []interface{}{"b"}}}
+		}
+		return nil
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+
+	if count, err := m.Where(Gt("Price", 1)).Count(); err != nil || count != 3 {
+		t.Errorf("expected count 3, got %d, %v", count, err)
+	}
+	if avg, err := m.Avg("Price"); err != nil || avg != 2.5 {
+		t.Errorf("expected avg 2.5, got %v, %v", avg, err)
+	}
+	if max, err := m.Max("price"); err != nil || max != 4.0 {
+		t.Errorf("expected max 4.0, got %#v, %v", max, err)
+	}
+	if sum, err := m.Sum("Price"); err != nil || sum != 0.0 {
+		t.Errorf("expected sum 0.0 for null, got %#v, %v", sum, err)
+	}
+	if first, err := m.Min("Name"); err != nil || first != "apple" {
+		t.Errorf("expected min apple, got %#v, %v", first, err)
+	}
+	items := newModel(&Client{driver: driver, config: &Config{}}, &aggTestItem{})
+	if total, err := items.Sum("Stock"); err != nil || total != int64(1<<62+1) {
+		t.Errorf("expected exact int64 sum, got %#v, %v", total, err)
+	}
+	if exists, err := m.Where("SKU = ?", "Z").Exists(); err != nil || exists {
+		t.Errorf("expected no match, got %v, %v", exists, err)
+	}
+
+	var names []string
+	if err := m.OrderBy("Name DESC").Distinct("Name", &names); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("unexpected distinct values: %v", names)
+	}
+	if err := m.OrderBy("Price").Distinct("Name", &names); err == nil {
+		t.Errorf("expected error when ordering Distinct by another field")
+	}
+	if _, err := m.Min("Color"); err == nil {
+		t.Errorf("expected error for unknown field")
+	}
+
+	expected := []string{
+		"MATCH (n:Product) WHERE n.price > $price_0 RETURN count(n)",
+		"MATCH (n:Product) RETURN avg(n.price)",
+		"MATCH (n:Product) RETURN max(n.price)",
+		"MATCH (n:Product) RETURN sum(n.price)",
+		"MATCH (n:Product) RETURN min(n.product_name)",
+		"MATCH (n:AggItem) RETURN sum(n.stock)",
+		"MATCH (n:Product) WHERE (n.sku = $p0) RETURN n LIMIT 1",
+		"MATCH (n:Product) RETURN DISTINCT n.product_name AS product_name  ORDER BY product_name DESC",
+	}
+	if !reflect.DeepEqual(driver.queries, expected) {
+		t.Errorf("expected queries %q, got %q", expected, driver.queries)
+	}
+}
@@ -1,7 +1,9 @@
 package neo4jorm
 
 import (
-	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"context"
+	"fmt"
+	"time"
 )
 
 type Config struct {
@@ -13,23 +15,26 @@ type Config struct {
 }
 
 type Client struct {
-	driver neo4j.Driver
+	driver graphDriver
 	config *Config
 	debug  bool
 }
 
 func NewClient(config *Config) (*Client, error) {
-	driver, err := neo4j.NewDriver(
-		config.URI,
-		neo4j.BasicAuth(config.Username, config.Password, ""),
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return NewClientCtx(ctx, config)
+}
+
+// NewClientCtx 创建客户端，ctx用于控制连通性校验的超时和取消
+func NewClientCtx(ctx context.Context, config *Config) (*Client, error) {
+	driver, err := newGraphDriver(config)
 	if err != nil {
 		return nil, err
 	}
 
-	// ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	// defer cancel()
-	if err = driver.VerifyConnectivity(); err != nil {
+	if err = driver.verifyConnectivity(ctx); err != nil {
+		driver.close(context.Background())
 		return nil, err
 	}
 
@@ -45,33 +50,116 @@ func (c *Client) Model(model interface{}) *Model {
 }
 
 func (c *Client) Close() error {
-	return c.driver.Close()
+	return c.driver.close(context.Background())
+}
+
+// newSession 按配置的数据库创建会话
+func (c *Client) newSession(ctx context.Context) graphSession {
+	return c.driver.newSession(ctx, c.config.Database)
+}
+
+// TxOption 事务配置项
+type TxOption func(*txConfig)
+
+type txConfig struct {
+	timeout  time.Duration
+	metadata map[string]interface{}
+}
+
+// WithTxTimeout 设置事务超时时间，由服务端强制执行
+func WithTxTimeout(timeout time.Duration) TxOption {
+	return func(c *txConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithTxMetadata 为事务附加元数据，可在 dbms.listTransactions 中查看
+func WithTxMetadata(metadata map[string]interface{}) TxOption {
+	return func(c *txConfig) {
+		c.metadata = metadata
+	}
+}
+
+// newTxConfig 合并事务配置项
+func newTxConfig(opts []TxOption) *txConfig {
+	config := &txConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
 }
 
 // 事务支持
 type Transaction struct {
-	session neo4j.Session
-	tx      neo4j.Transaction
+	client   *Client
+	ctx      context.Context // 开启事务时传入的上下文，事务内的操作默认使用它
+	session  graphSession
+	tx       graphTx
+	explicit graphExplicitTx // 显式事务，由 Commit/Rollback 结束
+}
+
+func (c *Client) BeginTx(opts ...TxOption) (*Transaction, error) {
+	return c.BeginTxCtx(context.Background(), opts...)
 }
 
-func (c *Client) BeginTx() (*Transaction, error) {
-	session := c.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: c.config.Database,
-	})
-	tx, err := session.BeginTransaction()
+// BeginTxCtx 开启显式事务，ctx取消时事务中正在执行的查询会被中止
+func (c *Client) BeginTxCtx(ctx context.Context, opts ...TxOption) (*Transaction, error) {
+	session := c.newSession(ctx)
+	tx, err := session.beginTransaction(ctx, newTxConfig(opts))
 	if err != nil {
-		session.Close()
+		session.close(context.Background())
 		return nil, err
 	}
-	return &Transaction{session: session, tx: tx}, nil
+	return &Transaction{client: c, ctx: ctx, session: session, tx: tx, explicit: tx}, nil
+}
+
+// Transaction 在写事务中执行fn，fn返回nil时提交，返回错误或panic时回滚，
+// panic会在回滚后重新抛出。事务通过驱动的托管写事务执行，遇到集群
+// 瞬时错误或主节点切换时会自动重试，因此fn可能被调用多次，不应包含事务之外的副作用
+func (c *Client) Transaction(fn func(tx *Transaction) error, opts ...TxOption) error {
+	return c.TransactionCtx(context.Background(), fn, opts...)
+}
+
+// TransactionCtx 同 Transaction，ctx取消或超时后停止重试并中止正在执行的查询
+func (c *Client) TransactionCtx(ctx context.Context, fn func(tx *Transaction) error, opts ...TxOption) error {
+	session := c.newSession(ctx)
+	defer session.close(context.Background())
+
+	var panicked interface{}
+	err := session.executeWrite(ctx, func(tx graphTx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicked = r
+				err = fmt.Errorf("transaction panicked: %v", r)
+			}
+		}()
+		return fn(&Transaction{client: c, ctx: ctx, tx: tx})
+	}, newTxConfig(opts))
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	return err
+}
+
+// Model 获取绑定到当前事务的模型，其所有读写操作都在该事务中执行
+func (t *Transaction) Model(model interface{}) *Model {
+	return newModel(t.client, model).WithTx(t)
 }
 
 func (t *Transaction) Commit() error {
-	defer t.session.Close()
-	return t.tx.Commit()
+	if t.explicit == nil {
+		return errManagedTx
+	}
+	defer t.session.close(context.Background())
+	return t.explicit.commit(t.ctx)
 }
 
 func (t *Transaction) Rollback() error {
-	defer t.session.Close()
-	return t.tx.Rollback()
+	if t.explicit == nil {
+		return errManagedTx
+	}
+	defer t.session.close(context.Background())
+	// 回滚不受调用方上下文取消的影响，保证事务能被正确结束
+	return t.explicit.rollback(context.Background())
 }
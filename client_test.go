@@ -0,0 +1,114 @@
+package neo4jorm
+
+import (
+	"errors"
+	"testing"
+)
+
+type txTestItem struct {
+	SKU   string  `neo4j:"name=sku,primary,table=Item"`
+	Price float64 `neo4j:"name=price"`
+}
+
+func TestTransaction(t *testing.T) {
+	driver := newFakeDriver()
+	client := &Client{driver: driver, config: &Config{}}
+
+	if err := client.Transaction(func(tx *Transaction) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if tx := driver.lastSession().txs[0]; !tx.committed || !driver.lastSession().closed {
+		t.Errorf("expected commit and closed session, got %+v", tx)
+	}
+
+	failed := errors.New("failed")
+	if err := client.Transaction(func(tx *Transaction) error { return failed }); err != failed {
+		t.Errorf("expected %v, got %v", failed, err)
+	}
+	if tx := driver.lastSession().txs[0]; tx.committed || !tx.rolledBack {
+		t.Errorf("expected rollback, got %+v", tx)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("expected panic to be re-raised, got %v", r)
+			}
+			if tx := driver.lastSession().txs[0]; !tx.rolledBack || !driver.lastSession().closed {
+				t.Errorf("expected rollback and closed session after panic, got %+v", tx)
+			}
+		}()
+		client.Transaction(func(tx *Transaction) error { panic("boom") })
+	}()
+
+	err := client.Transaction(func(tx *Transaction) error {
+		if err := tx.Commit(); err != errManagedTx {
+			t.Errorf("expected errManagedTx from Commit, got %v", err)
+		}
+		if err := tx.Rollback(); err != errManagedTx {
+			t.Errorf("expected errManagedTx from Rollback, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransactionModel(t *testing.T) {
+	driver := newFakeDriver(map[string]interface{}{"sku": "A"})
+	client := &Client{driver: driver, config: &Config{}}
+
+	tx, err := client.BeginTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := tx.Model(&txTestItem{})
+	if err := m.CreateOne(&txTestItem{SKU: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	var items []txTestItem
+	if err := m.Where("n.sku = $sku", map[string]interface{}{"sku": "A"}).Find(&items); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 读写都在绑定的事务中执行，不经过独立的会话
+	fake := driver.lastSession().txs[0]
+	if len(driver.queries) != 0 || len(fake.queries) != 2 {
+		t.Errorf("expected both queries in the transaction, got driver %q, tx %q", driver.queries, fake.queries)
+	}
+	if len(items) != 1 || items[0].SKU != "A" {
+		t.Errorf("unexpected results: %+v", items)
+	}
+	if !fake.committed || !driver.lastSession().closed {
+		t.Errorf("expected commit and closed session, got %+v", fake)
+	}
+
+	tx, err = client.BeginTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Model(&txTestItem{}).MergeOne(&txTestItem{SKU: "B"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if fake := driver.lastSession().txs[0]; fake.committed || !fake.rolledBack || len(fake.queries) != 1 {
+		t.Errorf("expected the merge to be rolled back, got %+v", fake)
+	}
+
+	// 托管事务中的模型同样使用该事务
+	err = client.Transaction(func(tx *Transaction) error {
+		return tx.Model(&txTestItem{}).Update(txTestItem{SKU: "A", Price: 1})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake := driver.lastSession().txs[0]; !fake.committed || len(fake.queries) != 1 {
+		t.Errorf("expected the update in the managed transaction, got %+v", fake)
+	}
+}
@@ -0,0 +1,291 @@
+package neo4jorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Condition 类型化查询条件，通过 Eq、Gt、In 等函数构造后传给 Model.Where。
+// 字段使用结构体字段名（也可以直接使用映射后的属性名），参数名自动生成，不会与其他条件冲突
+type Condition interface {
+	build(m *Model) (string, error)
+}
+
+// predicate 单个属性上的比较条件
+type predicate struct {
+	field string
+	op    string
+	value interface{}
+	unary bool // IS NULL 等不带参数的运算符
+}
+
+// Eq 等于
+func Eq(field string, value interface{}) Condition {
+	return predicate{field: field, op: "=", value: value}
+}
+
+// Neq 不等于
+func Neq(field string, value interface{}) Condition {
+	return predicate{field: field, op: "<>", value: value}
+}
+
+// Gt 大于
+func Gt(field string, value interface{}) Condition {
+	return predicate{field: field, op: ">", value: value}
+}
+
+// Gte 大于等于
+func Gte(field string, value interface{}) Condition {
+	return predicate{field: field, op: ">=", value: value}
+}
+
+// Lt 小于
+func Lt(field string, value interface{}) Condition {
+	return predicate{field: field, op: "<", value: value}
+}
+
+// Lte 小于等于
+func Lte(field string, value interface{}) Condition {
+	return predicate{field: field, op: "<=", value: value}
+}
+
+// In 属性值在列表中，values必须是切片或数组
+func In(field string, values interface{}) Condition {
+	return predicate{field: field, op: "IN", value: values}
+}
+
+// Contains 字符串包含
+func Contains(field string, value string) Condition {
+	return predicate{field: field, op: "CONTAINS", value: value}
+}
+
+// StartsWith 字符串前缀匹配
+func StartsWith(field string, value string) Condition {
+	return predicate{field: field, op: "STARTS WITH", value: value}
+}
+
+// EndsWith 字符串后缀匹配
+func EndsWith(field string, value string) Condition {
+	return predicate{field: field, op: "ENDS WITH", value: value}
+}
+
+// Regex 正则匹配，使用Java正则语法且需要匹配整个字符串
+func Regex(field string, pattern string) Condition {
+	return predicate{field: field, op: "=~", value: pattern}
+}
+
+// IsNull 属性不存在
+func IsNull(field string) Condition {
+	return predicate{field: field, op: "IS NULL", unary: true}
+}
+
+// IsNotNull 属性存在
+func IsNotNull(field string) Condition {
+	return predicate{field: field, op: "IS NOT NULL", unary: true}
+}
+
+func (p predicate) build(m *Model) (string, error) {
+	prop, err := m.propertyName(p.field)
+	if err != nil {
+		return "", err
+	}
+	if p.unary {
+		return fmt.Sprintf("n.%s %s", prop, p.op), nil
+	}
+
+	if p.op == "IN" {
+		kind := reflect.ValueOf(p.value).Kind()
+		if kind != reflect.Slice && kind != reflect.Array {
+			return "", fmt.Errorf("In(%s): expected slice/array, got %T", p.field, p.value)
+		}
+	}
+	value, err := m.propertyValue(prop, p.value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("n.%s %s $%s", prop, p.op, m.addParam(prop, value)), nil
+}
+
+// group 以 AND 或 OR 连接的条件组，生成的表达式带括号，可以任意嵌套
+type group struct {
+	op    string
+	conds []Condition
+}
+
+// And 所有条件同时成立
+func And(conds ...Condition) Condition {
+	return group{op: "AND", conds: conds}
+}
+
+// Or 任一条件成立，如 Or(Eq("Category", "book"), And(Gt("Price", 10), Lt("Price", 20)))
+func Or(conds ...Condition) Condition {
+	return group{op: "OR", conds: conds}
+}
+
+func (g group) build(m *Model) (string, error) {
+	if len(g.conds) == 0 {
+		return "", fmt.Errorf("%s requires at least one condition", strings.ToLower(g.op))
+	}
+	exprs := make([]string, 0, len(g.conds))
+	for _, c := range g.conds {
+		expr, err := c.build(m)
+		if err != nil {
+			return "", err
+		}
+		exprs = append(exprs, expr)
+	}
+	return "(" + strings.Join(exprs, " "+g.op+" ") + ")", nil
+}
+
+// not 取反条件
+type not struct {
+	cond Condition
+}
+
+// Not 条件不成立，条件组自带括号，单个条件的比较运算优先级高于 NOT
+func Not(cond Condition) Condition {
+	return not{cond: cond}
+}
+
+func (c not) build(m *Model) (string, error) {
+	if c.cond == nil {
+		return "", errors.New("not requires a condition")
+	}
+	expr, err := c.cond.build(m)
+	if err != nil {
+		return "", err
+	}
+	return "NOT " + expr, nil
+}
+
+// addParam 以属性名为前缀生成本次查询内唯一的参数名并保存参数值
+func (m *Model) addParam(prefix string, value interface{}) string {
+	return m.putParam(prefix+"_%d", value)
+}
+
+// putParam 按format（包含一个%d序号）生成本次查询内唯一的参数名并保存参数值，
+// 跳过字符串条件中已经使用的参数名
+func (m *Model) putParam(format string, value interface{}) string {
+	if m.params == nil {
+		m.params = make(map[string]interface{})
+	}
+	for {
+		name := fmt.Sprintf(format, m.paramSeq)
+		m.paramSeq++
+		if _, ok := m.params[name]; !ok {
+			m.params[name] = value
+			return name
+		}
+	}
+}
+
+// mergeParams 合并字符串条件的参数，参数名已存在且值不同时返回错误，避免静默覆盖其他条件的参数
+func (m *Model) mergeParams(params map[string]interface{}) error {
+	if m.params == nil {
+		m.params = make(map[string]interface{})
+	}
+	for k, v := range params {
+		if old, ok := m.params[k]; ok && !reflect.DeepEqual(old, v) {
+			return fmt.Errorf("where: parameter $%s is already defined with value %v", k, old)
+		}
+	}
+	for k, v := range params {
+		m.params[k] = v
+	}
+	return nil
+}
+
+// placeholder 字符串条件中 ? 占位符在改写过程中的临时标记
+const placeholder = "\x00"
+
+// stringCondition 改写字符串条件：结构体字段名替换为 n.<属性名>，? 依次替换为生成的参数 $p0、$p1...。
+// 字符串字面量、$参数、n.xxx 等属性访问、函数名和标签中的内容保持不变。
+// args为 ? 对应的参数，最后一个参数可以是命名参数的 map
+func (m *Model) stringCondition(expr string, args []interface{}) (string, error) {
+	out, err := m.rewriteFields(expr, "n")
+	if err != nil {
+		return "", err
+	}
+
+	count := strings.Count(out, placeholder)
+	if len(args) == count+1 {
+		named, ok := args[count].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("where %q: expected %d arguments, got %d", expr, count, len(args))
+		}
+		if err := m.mergeParams(named); err != nil {
+			return "", err
+		}
+		args = args[:count]
+	}
+	if len(args) != count {
+		return "", fmt.Errorf("where %q: expected %d arguments, got %d", expr, count, len(args))
+	}
+
+	for _, arg := range args {
+		out = strings.Replace(out, placeholder, "$"+m.putParam("p%d", arg), 1)
+	}
+	return out, nil
+}
+
+// rewriteFields 扫描字符串条件，将字段名替换为 alias.<属性名> 并将 ? 标记为占位符
+func (m *Model) rewriteFields(expr string, alias string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// 字符串字面量和反引号标识符原样保留
+			j := i + 1
+			for j < len(expr) && expr[j] != c {
+				if expr[j] == '\\' && c != '`' {
+					j++
+				}
+				j++
+			}
+			if j >= len(expr) {
+				return "", fmt.Errorf("where %q: unterminated %c", expr, c)
+			}
+			sb.WriteString(expr[i : j+1])
+			i = j + 1
+		case c == '?':
+			sb.WriteString(placeholder)
+			i++
+		case c == '$':
+			// 命名参数原样保留
+			j := i + 1
+			for j < len(expr) && isIdentChar(expr[j]) {
+				j++
+			}
+			sb.WriteString(expr[i:j])
+			i = j
+		case isIdentChar(c) && (c < '0' || c > '9'):
+			j := i
+			for j < len(expr) && isIdentChar(expr[j]) {
+				j++
+			}
+			word := expr[i:j]
+			prev := strings.TrimRight(expr[:i], " \t\n")
+			next := strings.TrimLeft(expr[j:], " \t\n")
+			prop, ok := m.fieldMap[word]
+			// 属性访问（.Name）、标签（:Name）和函数调用（Name(）不是字段名
+			if ok && !strings.HasSuffix(prev, ".") && !strings.HasSuffix(prev, ":") && !strings.HasPrefix(next, "(") {
+				sb.WriteString(alias + "." + prop)
+			} else {
+				sb.WriteString(word)
+			}
+			i = j
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String(), nil
+}
+
+// isIdentChar 是否为标识符字符
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
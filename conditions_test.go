@@ -0,0 +1,166 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type condTestProduct struct {
+	SKU   string  `neo4j:"name=sku,primary,label=Product"`
+	Name  string  `neo4j:"name=product_name"`
+	Price float64 `neo4j:"name=price"`
+	Note  *string `neo4j:"name=note"`
+}
+
+func TestWhereConditions(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where(
+		Gt("Price", 10),
+		In("SKU", []string{"A", "B"}),
+		StartsWith("product_name", "Neo"),
+		IsNull("Note"),
+	)
+	if m.err != nil {
+		t.Fatal(m.err)
+	}
+
+	expected := "MATCH (n:Product) WHERE n.price > $price_0 AND n.sku IN $sku_1 " +
+		"AND n.product_name STARTS WITH $product_name_2 AND n.note IS NULL RETURN n "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	params := map[string]interface{}{"price_0": 10, "sku_1": []string{"A", "B"}, "product_name_2": "Neo"}
+	if !reflect.DeepEqual(m.params, params) {
+		t.Errorf("expected params %v, got %v", params, m.params)
+	}
+
+	if err := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where(Eq("Color", "red")).Find(&[]condTestProduct{}); err == nil {
+		t.Errorf("expected error for unknown field")
+	}
+	if err := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where(In("SKU", "A")).Find(&[]condTestProduct{}); err == nil {
+		t.Errorf("expected error for In with a non-slice value")
+	}
+}
+
+func TestConditionGroups(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where(
+		Or(Eq("Name", "neo"), And(Gt("Price", 10), Lt("Price", 20))),
+		Not(IsNull("Note")),
+	)
+	expected := "MATCH (n:Product) WHERE (n.product_name = $product_name_0 OR (n.price > $price_1 AND n.price < $price_2)) " +
+		"AND NOT n.note IS NULL RETURN n "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	if len(m.params) != 3 {
+		t.Errorf("expected 3 params, got %v", m.params)
+	}
+
+	m = newModel(&Client{config: &Config{}}, &condTestProduct{}).
+		Where("n.price > 10 OR n.price < 1").
+		Where(Eq("SKU", "A")).
+		Or(Eq("SKU", "B")).
+		Not(StartsWith("Name", "old"), Lt("Price", 5))
+	expected = "MATCH (n:Product) WHERE (((n.price > 10 OR n.price < 1) AND n.sku = $sku_0) OR (n.sku = $sku_1)) " +
+		"AND NOT (n.product_name STARTS WITH $product_name_2 AND n.price < $price_3) RETURN n "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	if err := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where(Or()).Find(&[]condTestProduct{}); err == nil {
+		t.Errorf("expected error for empty Or")
+	}
+}
+
+func TestWhereChaining(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &condTestProduct{}).
+		Where(condTestProduct{SKU: "A", Price: 9.9}).
+		Where("n.product_name = $name", map[string]interface{}{"name": "neo", "price_2": 1}).
+		Where(Gt("Price", 1)).
+		Where(&condTestProduct{Name: "graph"})
+	if m.err != nil {
+		t.Fatal(m.err)
+	}
+
+	expected := "MATCH (n:Product) WHERE n.sku = $sku_0 AND n.price = $price_1 AND (n.product_name = $name) " +
+		"AND n.price > $price_3 AND n.product_name = $product_name_4 RETURN n "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	params := map[string]interface{}{
+		"sku_0": "A", "price_1": 9.9, "name": "neo", "price_2": 1, "price_3": 1, "product_name_4": "graph",
+	}
+	if !reflect.DeepEqual(m.params, params) {
+		t.Errorf("expected params %v, got %v", params, m.params)
+	}
+
+	err := newModel(&Client{config: &Config{}}, &condTestProduct{}).
+		Where("n.sku = $sku", map[string]interface{}{"sku": "A"}).
+		Where("n.product_name = $sku", map[string]interface{}{"sku": "B"}).
+		Find(&[]condTestProduct{})
+	if err == nil || !strings.Contains(err.Error(), "$sku") {
+		t.Errorf("expected parameter clash error, got %v", err)
+	}
+
+	// 写入返回链式条件上的错误，错误不会遗留到之后的查询
+	driver := newFakeDriver()
+	m = newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+	if err := m.Where(Eq("Nope", 1)).CreateOne(&condTestProduct{SKU: "A"}); err == nil {
+		t.Errorf("expected the condition error from CreateOne")
+	}
+	if err := m.Find(&[]condTestProduct{}); err != nil {
+		t.Errorf("expected the error to be cleared, got %v", err)
+	}
+	if len(driver.queries) != 1 || driver.queries[0] != "MATCH (n:Product) RETURN n " {
+		t.Errorf("expected only the find query, got %q", driver.queries)
+	}
+}
+
+func TestWherePlaceholders(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &condTestProduct{}).
+		Where("SKU = ? AND Price > ? AND n.Name <> 'Name?' AND toLower(Name) = $name", "A", 10, map[string]interface{}{"name": "neo"}).
+		Or("Note IS NULL AND SKU IN ?", []string{"B", "C"})
+	if m.err != nil {
+		t.Fatal(m.err)
+	}
+
+	expected := "MATCH (n:Product) WHERE (((n.sku = $p0 AND n.price > $p1 AND n.Name <> 'Name?' AND toLower(n.product_name) = $name)) " +
+		"OR ((n.note IS NULL AND n.sku IN $p2))) RETURN n "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	params := map[string]interface{}{"p0": "A", "p1": 10, "name": "neo", "p2": []string{"B", "C"}}
+	if !reflect.DeepEqual(m.params, params) {
+		t.Errorf("expected params %v, got %v", params, m.params)
+	}
+
+	if err := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where("SKU = ?").Find(&[]condTestProduct{}); err == nil {
+		t.Errorf("expected error for missing argument")
+	}
+	if err := newModel(&Client{config: &Config{}}, &condTestProduct{}).Where("SKU = 'A'", "B").Find(&[]condTestProduct{}); err == nil {
+		t.Errorf("expected error for unused argument")
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &condTestProduct{}).
+		OrderBy("Price DESC", "product_name").
+		OrderBy(Asc("size(Name)"), Desc("n.sku"))
+	expected := "MATCH (n:Product) RETURN n  ORDER BY n.price DESC, n.product_name, size(n.product_name) ASC, n.sku DESC"
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	own := newModel(&Client{config: &Config{}}, &relTestOwnership{}).OrderBy("Since desc", "s.name")
+	expected = "MATCH (s:User)-[n:OWNS]->(e:Project) RETURN n, s, e  ORDER BY n.since DESC, s.name"
+	if query := own.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	friends := newModel(&Client{config: &Config{}}, &relTestUser{}).Traverse("OWNS", Outgoing).OrderBy("Title")
+	friends.bindTarget(&[]relTestProject{})
+	expected = "MATCH (n:User) MATCH p = (n)-[:OWNS]->(t0:Project) RETURN DISTINCT t0  ORDER BY t0.title"
+	if query := friends.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+}
@@ -0,0 +1,85 @@
+package neo4jorm
+
+import (
+	"context"
+	"testing"
+)
+
+type ctxTestKey struct{}
+
+func TestContextMethods(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		return []*record{
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "A"}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "B"}}}},
+		}
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+	ctx := context.WithValue(context.Background(), ctxTestKey{}, "find")
+
+	var a, b []condTestProduct
+	if err := m.Where(Eq("SKU", "A")).FindCtx(ctx, &a); err != nil {
+		t.Fatal(err)
+	}
+	if driver.ctx.Value(ctxTestKey{}) != "find" {
+		t.Errorf("expected the query to run with the given context")
+	}
+	if m.ctx != nil {
+		t.Errorf("expected the model context to be restored")
+	}
+
+	// 同一个模型上的条件在每次查询后清理，不会累积到下一次查询
+	if err := m.Where(Eq("SKU", "B")).After("").Limit(2).FindCtx(ctx, &b); err != nil {
+		t.Fatal(err)
+	}
+	expected := "MATCH (n:Product) WHERE n.sku = $sku_0 RETURN n  ORDER BY n.sku LIMIT 2"
+	if driver.queries[1] != expected {
+		t.Errorf("expected %q, got %q", expected, driver.queries[1])
+	}
+	if m.Cursor() == "" {
+		t.Errorf("expected the cursor to be set on the model")
+	}
+}
+
+func TestContextPropagation(t *testing.T) {
+	driver := newFakeDriver()
+	client := &Client{driver: driver, config: &Config{}}
+	m := newModel(client, &txTestItem{})
+
+	writeCtx := context.WithValue(context.Background(), ctxTestKey{}, "write")
+	if err := m.CreateBatchCtx(writeCtx, []txTestItem{{SKU: "A"}}); err != nil {
+		t.Fatal(err)
+	}
+	if driver.ctx.Value(ctxTestKey{}) != "write" {
+		t.Errorf("expected the write to run with the given context")
+	}
+
+	// 事务内的操作默认使用开启事务时的上下文，WithContext 可以覆盖
+	txCtx := context.WithValue(context.Background(), ctxTestKey{}, "tx")
+	tx, err := client.BeginTxCtx(txCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := driver.lastSession().txs[0]
+	if fake.ctx.Value(ctxTestKey{}) != "tx" {
+		t.Errorf("expected the transaction to begin with the given context")
+	}
+	if err := tx.Model(&txTestItem{}).DeleteOne(&txTestItem{SKU: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.runCtx.Value(ctxTestKey{}) != "tx" {
+		t.Errorf("expected the transaction context to be used")
+	}
+	if err := tx.Model(&txTestItem{}).WithContext(writeCtx).DeleteOne(&txTestItem{SKU: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.runCtx.Value(ctxTestKey{}) != "write" {
+		t.Errorf("expected WithContext to override the transaction context")
+	}
+	tx.Rollback()
+
+	err = client.TransactionCtx(txCtx, func(tx *Transaction) error { return nil })
+	if err != nil || driver.lastSession().txs[0].ctx.Value(ctxTestKey{}) != "tx" {
+		t.Errorf("expected the managed transaction to use the given context, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package neo4jorm
+
+import "context"
+
+// graphDriver 屏蔽不同版本官方驱动的差异。默认使用v5驱动，
+// 使用 -tags neo4j_v4 编译时切换为v4驱动，见 driver_v5.go 和 driver_v4.go
+type graphDriver interface {
+	verifyConnectivity(ctx context.Context) error
+	close(ctx context.Context) error
+	newSession(ctx context.Context, database string) graphSession
+	// executeQuery 在自动重试的事务中执行单条语句并一次性读取全部结果，
+	// write为false时路由到只读节点
+	executeQuery(ctx context.Context, database string, query string, params map[string]interface{}, write bool, config *txConfig) ([]*record, error)
+}
+
+// graphSession 驱动会话
+type graphSession interface {
+	run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error)
+	beginTransaction(ctx context.Context, config *txConfig) (graphExplicitTx, error)
+	// executeWrite 在写事务中执行work，遇到瞬时错误时自动重试
+	executeWrite(ctx context.Context, work func(tx graphTx) error, config *txConfig) error
+	close(ctx context.Context) error
+}
+
+// graphTx 事务内执行语句的能力，托管事务和显式事务都实现该接口
+type graphTx interface {
+	run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error)
+}
+
+// graphExplicitTx 显式事务，需要手动提交或回滚
+type graphExplicitTx interface {
+	graphTx
+	commit(ctx context.Context) error
+	rollback(ctx context.Context) error
+}
+
+// graphResult 查询结果游标
+type graphResult interface {
+	next(ctx context.Context) bool
+	record() *record
+	err() error
+	consume(ctx context.Context) error
+}
+
+// record 驱动无关的结果记录，节点、关系和路径已转换为 graphNode、graphRelationship 和 graphPath
+type record struct {
+	keys   []string
+	values []interface{}
+}
+
+// graphNode 驱动无关的节点
+type graphNode struct {
+	id     string
+	labels []string
+	props  map[string]interface{}
+}
+
+// graphRelationship 驱动无关的关系
+type graphRelationship struct {
+	id      string
+	startID string
+	endID   string
+	relType string
+	props   map[string]interface{}
+}
+
+// graphPath 驱动无关的路径
+type graphPath struct {
+	nodes         []*graphNode
+	relationships []*graphRelationship
+}
+
+// collectRecords 读取结果游标中的全部记录
+func collectRecords(ctx context.Context, result graphResult) ([]*record, error) {
+	var records []*record
+	for result.next(ctx) {
+		records = append(records, result.record())
+	}
+	if err := result.err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,316 @@
+//go:build neo4j_v4
+
+package neo4jorm
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+)
+
+// v4Driver 基于 neo4j-go-driver/v4 的驱动实现，用于仍运行 Neo4j 4.x 的环境。
+// v4驱动不支持上下文，只能在执行前检查ctx是否已取消，并把ctx的截止时间作为事务超时交给服务端执行
+type v4Driver struct {
+	driver neo4j.Driver
+}
+
+func newGraphDriver(config *Config) (graphDriver, error) {
+	driver, err := neo4j.NewDriver(
+		config.URI,
+		neo4j.BasicAuth(config.Username, config.Password, ""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &v4Driver{driver: driver}, nil
+}
+
+func (d *v4Driver) verifyConnectivity(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return d.driver.VerifyConnectivity()
+}
+
+func (d *v4Driver) close(ctx context.Context) error {
+	return d.driver.Close()
+}
+
+func (d *v4Driver) newSession(ctx context.Context, database string) graphSession {
+	return &v4Session{session: d.driver.NewSession(neo4j.SessionConfig{
+		DatabaseName: database,
+	})}
+}
+
+func (d *v4Driver) executeQuery(ctx context.Context, database string, query string, params map[string]interface{}, write bool, config *txConfig) ([]*record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	accessMode := neo4j.AccessModeRead
+	if write {
+		accessMode = neo4j.AccessModeWrite
+	}
+	session := d.driver.NewSession(neo4j.SessionConfig{
+		DatabaseName: database,
+		AccessMode:   accessMode,
+	})
+	defer session.Close()
+
+	work := func(tx neo4j.Transaction) (interface{}, error) {
+		result, err := tx.Run(query, toV4Params(params))
+		if err != nil {
+			return nil, err
+		}
+		return collectRecords(ctx, &v4Result{result: result})
+	}
+
+	var (
+		records interface{}
+		err     error
+	)
+	if write {
+		records, err = session.WriteTransaction(work, v4TxConfigurers(ctx, config)...)
+	} else {
+		records, err = session.ReadTransaction(work, v4TxConfigurers(ctx, config)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return records.([]*record), nil
+}
+
+type v4Session struct {
+	session neo4j.Session
+}
+
+func (s *v4Session) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := s.session.Run(query, toV4Params(params), v4TxConfigurers(ctx, nil)...)
+	if err != nil {
+		return nil, err
+	}
+	return &v4Result{result: result}, nil
+}
+
+func (s *v4Session) beginTransaction(ctx context.Context, config *txConfig) (graphExplicitTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	tx, err := s.session.BeginTransaction(v4TxConfigurers(ctx, config)...)
+	if err != nil {
+		return nil, err
+	}
+	return &v4Tx{tx: tx}, nil
+}
+
+func (s *v4Session) executeWrite(ctx context.Context, work func(tx graphTx) error, config *txConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, err := s.session.WriteTransaction(func(tx neo4j.Transaction) (interface{}, error) {
+		return nil, work(&v4Tx{tx: tx})
+	}, v4TxConfigurers(ctx, config)...)
+	return err
+}
+
+func (s *v4Session) close(ctx context.Context) error {
+	return s.session.Close()
+}
+
+// v4Tx v4驱动的托管事务与显式事务共用同一接口
+type v4Tx struct {
+	tx neo4j.Transaction
+}
+
+func (t *v4Tx) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := t.tx.Run(query, toV4Params(params))
+	if err != nil {
+		return nil, err
+	}
+	return &v4Result{result: result}, nil
+}
+
+func (t *v4Tx) commit(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return t.tx.Commit()
+}
+
+func (t *v4Tx) rollback(ctx context.Context) error {
+	return t.tx.Rollback()
+}
+
+type v4Result struct {
+	result neo4j.Result
+	ctxErr error
+}
+
+func (r *v4Result) next(ctx context.Context) bool {
+	// 逐条读取时检查上下文，取消后不再拉取剩余记录
+	if err := ctx.Err(); err != nil {
+		r.ctxErr = err
+		return false
+	}
+	return r.result.Next()
+}
+
+func (r *v4Result) record() *record {
+	return fromV4Record(r.result.Record())
+}
+
+func (r *v4Result) err() error {
+	if r.ctxErr != nil {
+		return r.ctxErr
+	}
+	return r.result.Err()
+}
+
+func (r *v4Result) consume(ctx context.Context) error {
+	_, err := r.result.Consume()
+	return err
+}
+
+// v4TxConfigurers 将事务配置转换为驱动的配置函数，未设置超时时使用ctx的剩余时间
+func v4TxConfigurers(ctx context.Context, config *txConfig) []func(*neo4j.TransactionConfig) {
+	var configurers []func(*neo4j.TransactionConfig)
+	if config == nil {
+		config = &txConfig{}
+	}
+
+	timeout := config.timeout
+	if deadline, ok := ctx.Deadline(); ok && timeout <= 0 {
+		timeout = time.Until(deadline)
+	}
+	if timeout > 0 {
+		configurers = append(configurers, neo4j.WithTxTimeout(timeout))
+	}
+	if config.metadata != nil {
+		configurers = append(configurers, neo4j.WithTxMetadata(config.metadata))
+	}
+	return configurers
+}
+
+func fromV4Record(r *neo4j.Record) *record {
+	values := make([]interface{}, len(r.Values))
+	for i, v := range r.Values {
+		values[i] = fromV4Value(v)
+	}
+	return &record{keys: r.Keys, values: values}
+}
+
+// fromV4Value 将v4驱动返回的图类型转换为驱动无关的类型，时间类型转换为 time.Time 和 time.Duration，
+// 其他类型原样返回
+func fromV4Value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case neo4j.Node:
+		return fromV4Node(val)
+	case neo4j.Relationship:
+		return fromV4Relationship(val)
+	case neo4j.Path:
+		path := &graphPath{}
+		for _, n := range val.Nodes {
+			path.nodes = append(path.nodes, fromV4Node(n))
+		}
+		for _, r := range val.Relationships {
+			path.relationships = append(path.relationships, fromV4Relationship(r))
+		}
+		return path
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = fromV4Value(item)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = fromV4Value(item)
+		}
+		return m
+	case neo4j.Date:
+		return val.Time()
+	case neo4j.LocalDateTime:
+		return val.Time()
+	case neo4j.LocalTime:
+		return val.Time()
+	case neo4j.Time:
+		return val.Time()
+	case neo4j.Duration:
+		return durationOf(val.Months, val.Days, val.Seconds, val.Nanos)
+	default:
+		return v
+	}
+}
+
+func fromV4Node(n neo4j.Node) *graphNode {
+	return &graphNode{id: strconv.FormatInt(n.Id, 10), labels: n.Labels, props: fromV4Props(n.Props)}
+}
+
+func fromV4Relationship(r neo4j.Relationship) *graphRelationship {
+	return &graphRelationship{
+		id:      strconv.FormatInt(r.Id, 10),
+		startID: strconv.FormatInt(r.StartId, 10),
+		endID:   strconv.FormatInt(r.EndId, 10),
+		relType: r.Type,
+		props:   fromV4Props(r.Props),
+	}
+}
+
+// fromV4Props 转换节点或关系属性中的时间值
+func fromV4Props(props map[string]interface{}) map[string]interface{} {
+	converted, _ := fromV4Value(props).(map[string]interface{})
+	return converted
+}
+
+// toV4Params 将参数中驱动无关的时间值转换为v4驱动的时间类型
+func toV4Params(params map[string]interface{}) map[string]interface{} {
+	converted, _ := toV4Value(params).(map[string]interface{})
+	return converted
+}
+
+// toV4Value 将指定了存储类型的时间转换为 date 或 localdatetime，time.Duration 转换为 duration，
+// 递归处理列表和map，其他值原样返回
+func toV4Value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case graphTemporal:
+		if val.storage == storageDate {
+			return neo4j.Date(dateOf(val.t))
+		}
+		return neo4j.LocalDateTime(val.t)
+	case time.Duration:
+		seconds, nanos := splitDuration(val)
+		return neo4j.Duration{Seconds: seconds, Nanos: nanos}
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = toV4Value(item)
+		}
+		return list
+	case []map[string]interface{}:
+		list := make([]map[string]interface{}, len(val))
+		for i, item := range val {
+			list[i] = toV4Params(item)
+		}
+		return list
+	case map[string]interface{}:
+		if val == nil {
+			return val
+		}
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = toV4Value(item)
+		}
+		return m
+	default:
+		return v
+	}
+}
@@ -0,0 +1,62 @@
+//go:build neo4j_v4
+
+package neo4jorm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+)
+
+func TestFromV4Record(t *testing.T) {
+	user := neo4j.Node{Id: 1, Labels: []string{"User"}, Props: map[string]interface{}{"id": "U001"}}
+	project := neo4j.Node{Id: 2, Labels: []string{"Project"}, Props: map[string]interface{}{"id": "P001"}}
+	owns := neo4j.Relationship{Id: 3, StartId: 1, EndId: 2, Type: "OWNS", Props: map[string]interface{}{"role": "Owner"}}
+
+	rec := fromV4Record(&neo4j.Record{
+		Keys: []string{"u", "r", "p"},
+		Values: []interface{}{
+			user,
+			owns,
+			neo4j.Path{Nodes: []neo4j.Node{user, project}, Relationships: []neo4j.Relationship{owns}},
+		},
+	})
+
+	expectedUser := &graphNode{id: "1", labels: []string{"User"}, props: map[string]interface{}{"id": "U001"}}
+	if !reflect.DeepEqual(rec.values[0], expectedUser) {
+		t.Errorf("expected %+v, got %+v", expectedUser, rec.values[0])
+	}
+	rel, ok := rec.values[1].(*graphRelationship)
+	if !ok || rel.id != "3" || rel.startID != "1" || rel.endID != "2" || rel.relType != "OWNS" {
+		t.Errorf("unexpected relationship: %+v", rec.values[1])
+	}
+	path, ok := rec.values[2].(*graphPath)
+	if !ok || len(path.nodes) != 2 || len(path.relationships) != 1 || path.nodes[1].id != "2" {
+		t.Errorf("unexpected path: %+v", rec.values[2])
+	}
+}
+
+func TestV4TxConfigurers(t *testing.T) {
+	// 未设置超时时使用上下文的剩余时间
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var config neo4j.TransactionConfig
+	for _, configure := range v4TxConfigurers(ctx, nil) {
+		configure(&config)
+	}
+	if config.Timeout <= 0 || config.Timeout > time.Minute {
+		t.Errorf("expected timeout from the context deadline, got %v", config.Timeout)
+	}
+
+	config = neo4j.TransactionConfig{}
+	for _, configure := range v4TxConfigurers(ctx, &txConfig{timeout: time.Second}) {
+		configure(&config)
+	}
+	if config.Timeout != time.Second {
+		t.Errorf("expected explicit timeout to win, got %v", config.Timeout)
+	}
+}
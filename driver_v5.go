@@ -0,0 +1,283 @@
+//go:build !neo4j_v4
+
+package neo4jorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// v5Driver 基于 neo4j-go-driver/v5 的驱动实现
+type v5Driver struct {
+	driver neo4j.DriverWithContext
+}
+
+func newGraphDriver(config *Config) (graphDriver, error) {
+	driver, err := neo4j.NewDriverWithContext(
+		config.URI,
+		neo4j.BasicAuth(config.Username, config.Password, ""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &v5Driver{driver: driver}, nil
+}
+
+func (d *v5Driver) verifyConnectivity(ctx context.Context) error {
+	return d.driver.VerifyConnectivity(ctx)
+}
+
+func (d *v5Driver) close(ctx context.Context) error {
+	return d.driver.Close(ctx)
+}
+
+func (d *v5Driver) newSession(ctx context.Context, database string) graphSession {
+	return &v5Session{session: d.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: database,
+		// 与 ExecuteQuery 共用书签，保证会话能读到 ExecuteQuery 的写入
+		BookmarkManager: d.driver.ExecuteQueryBookmarkManager(),
+	})}
+}
+
+func (d *v5Driver) executeQuery(ctx context.Context, database string, query string, params map[string]interface{}, write bool, config *txConfig) ([]*record, error) {
+	routing := neo4j.ExecuteQueryWithReadersRouting()
+	if write {
+		routing = neo4j.ExecuteQueryWithWritersRouting()
+	}
+
+	result, err := neo4j.ExecuteQuery(ctx, d.driver, query, toV5Params(params),
+		neo4j.EagerResultTransformer,
+		neo4j.ExecuteQueryWithDatabase(database),
+		neo4j.ExecuteQueryWithTransactionConfig(v5TxConfigurers(config)...),
+		routing,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*record, 0, len(result.Records))
+	for _, r := range result.Records {
+		records = append(records, fromV5Record(r))
+	}
+	return records, nil
+}
+
+type v5Session struct {
+	session neo4j.SessionWithContext
+}
+
+func (s *v5Session) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	result, err := s.session.Run(ctx, query, toV5Params(params))
+	if err != nil {
+		return nil, err
+	}
+	return &v5Result{result: result}, nil
+}
+
+func (s *v5Session) beginTransaction(ctx context.Context, config *txConfig) (graphExplicitTx, error) {
+	tx, err := s.session.BeginTransaction(ctx, v5TxConfigurers(config)...)
+	if err != nil {
+		return nil, err
+	}
+	return &v5ExplicitTx{tx: tx}, nil
+}
+
+func (s *v5Session) executeWrite(ctx context.Context, work func(tx graphTx) error, config *txConfig) error {
+	_, err := s.session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return nil, work(&v5Tx{tx: tx})
+	}, v5TxConfigurers(config)...)
+	return err
+}
+
+func (s *v5Session) close(ctx context.Context) error {
+	return s.session.Close(ctx)
+}
+
+// v5Tx 托管事务
+type v5Tx struct {
+	tx neo4j.ManagedTransaction
+}
+
+func (t *v5Tx) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	result, err := t.tx.Run(ctx, query, toV5Params(params))
+	if err != nil {
+		return nil, err
+	}
+	return &v5Result{result: result}, nil
+}
+
+// v5ExplicitTx 显式事务
+type v5ExplicitTx struct {
+	tx neo4j.ExplicitTransaction
+}
+
+func (t *v5ExplicitTx) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	result, err := t.tx.Run(ctx, query, toV5Params(params))
+	if err != nil {
+		return nil, err
+	}
+	return &v5Result{result: result}, nil
+}
+
+func (t *v5ExplicitTx) commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+func (t *v5ExplicitTx) rollback(ctx context.Context) error {
+	return t.tx.Rollback(ctx)
+}
+
+type v5Result struct {
+	result neo4j.ResultWithContext
+}
+
+func (r *v5Result) next(ctx context.Context) bool {
+	return r.result.Next(ctx)
+}
+
+func (r *v5Result) record() *record {
+	return fromV5Record(r.result.Record())
+}
+
+func (r *v5Result) err() error {
+	return r.result.Err()
+}
+
+func (r *v5Result) consume(ctx context.Context) error {
+	_, err := r.result.Consume(ctx)
+	return err
+}
+
+// v5TxConfigurers 将事务配置转换为驱动的配置函数
+func v5TxConfigurers(config *txConfig) []func(*neo4j.TransactionConfig) {
+	var configurers []func(*neo4j.TransactionConfig)
+	if config == nil {
+		return configurers
+	}
+	if config.timeout > 0 {
+		configurers = append(configurers, neo4j.WithTxTimeout(config.timeout))
+	}
+	if config.metadata != nil {
+		configurers = append(configurers, neo4j.WithTxMetadata(config.metadata))
+	}
+	return configurers
+}
+
+func fromV5Record(r *neo4j.Record) *record {
+	values := make([]interface{}, len(r.Values))
+	for i, v := range r.Values {
+		values[i] = fromV5Value(v)
+	}
+	return &record{keys: r.Keys, values: values}
+}
+
+// fromV5Value 将v5驱动返回的图类型转换为驱动无关的类型，时间类型转换为 time.Time 和 time.Duration，
+// 其他类型原样返回
+func fromV5Value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case dbtype.Node:
+		return fromV5Node(val)
+	case dbtype.Relationship:
+		return fromV5Relationship(val)
+	case dbtype.Path:
+		path := &graphPath{}
+		for _, n := range val.Nodes {
+			path.nodes = append(path.nodes, fromV5Node(n))
+		}
+		for _, r := range val.Relationships {
+			path.relationships = append(path.relationships, fromV5Relationship(r))
+		}
+		return path
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = fromV5Value(item)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = fromV5Value(item)
+		}
+		return m
+	case dbtype.Date:
+		return val.Time()
+	case dbtype.LocalDateTime:
+		return val.Time()
+	case dbtype.LocalTime:
+		return val.Time()
+	case dbtype.Time:
+		return val.Time()
+	case dbtype.Duration:
+		return durationOf(val.Months, val.Days, val.Seconds, val.Nanos)
+	default:
+		return v
+	}
+}
+
+func fromV5Node(n dbtype.Node) *graphNode {
+	return &graphNode{id: n.ElementId, labels: n.Labels, props: fromV5Props(n.Props)}
+}
+
+func fromV5Relationship(r dbtype.Relationship) *graphRelationship {
+	return &graphRelationship{
+		id:      r.ElementId,
+		startID: r.StartElementId,
+		endID:   r.EndElementId,
+		relType: r.Type,
+		props:   fromV5Props(r.Props),
+	}
+}
+
+// fromV5Props 转换节点或关系属性中的时间值
+func fromV5Props(props map[string]interface{}) map[string]interface{} {
+	converted, _ := fromV5Value(props).(map[string]interface{})
+	return converted
+}
+
+// toV5Params 将参数中驱动无关的时间值转换为v5驱动的时间类型
+func toV5Params(params map[string]interface{}) map[string]interface{} {
+	converted, _ := toV5Value(params).(map[string]interface{})
+	return converted
+}
+
+// toV5Value 将指定了存储类型的时间转换为 date 或 localdatetime，time.Duration 转换为 duration，
+// 递归处理列表和map，其他值原样返回
+func toV5Value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case graphTemporal:
+		if val.storage == storageDate {
+			return dbtype.Date(dateOf(val.t))
+		}
+		return dbtype.LocalDateTime(val.t)
+	case time.Duration:
+		seconds, nanos := splitDuration(val)
+		return dbtype.Duration{Seconds: seconds, Nanos: nanos}
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = toV5Value(item)
+		}
+		return list
+	case []map[string]interface{}:
+		list := make([]map[string]interface{}, len(val))
+		for i, item := range val {
+			list[i] = toV5Params(item)
+		}
+		return list
+	case map[string]interface{}:
+		if val == nil {
+			return val
+		}
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = toV5Value(item)
+		}
+		return m
+	default:
+		return v
+	}
+}
@@ -0,0 +1,61 @@
+//go:build !neo4j_v4
+
+package neo4jorm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+func TestFromV5Record(t *testing.T) {
+	user := dbtype.Node{ElementId: "4:a:1", Labels: []string{"User"}, Props: map[string]interface{}{"id": "U001"}}
+	project := dbtype.Node{ElementId: "4:a:2", Labels: []string{"Project"}, Props: map[string]interface{}{"id": "P001"}}
+	owns := dbtype.Relationship{ElementId: "5:a:3", StartElementId: "4:a:1", EndElementId: "4:a:2", Type: "OWNS",
+		Props: map[string]interface{}{"role": "Owner"}}
+
+	rec := fromV5Record(&neo4j.Record{
+		Keys: []string{"u", "r", "p", "nested"},
+		Values: []interface{}{
+			user,
+			owns,
+			dbtype.Path{Nodes: []dbtype.Node{user, project}, Relationships: []dbtype.Relationship{owns}},
+			map[string]interface{}{"projects": []interface{}{project}},
+		},
+	})
+
+	expectedUser := &graphNode{id: "4:a:1", labels: []string{"User"}, props: map[string]interface{}{"id": "U001"}}
+	if !reflect.DeepEqual(rec.values[0], expectedUser) {
+		t.Errorf("expected %+v, got %+v", expectedUser, rec.values[0])
+	}
+	rel, ok := rec.values[1].(*graphRelationship)
+	if !ok || rel.id != "5:a:3" || rel.startID != "4:a:1" || rel.endID != "4:a:2" || rel.relType != "OWNS" {
+		t.Errorf("unexpected relationship: %+v", rec.values[1])
+	}
+	path, ok := rec.values[2].(*graphPath)
+	if !ok || len(path.nodes) != 2 || len(path.relationships) != 1 || path.nodes[1].id != "4:a:2" {
+		t.Errorf("unexpected path: %+v", rec.values[2])
+	}
+	nested := rec.values[3].(map[string]interface{})["projects"].([]interface{})
+	if node, ok := nested[0].(*graphNode); !ok || node.props["id"] != "P001" {
+		t.Errorf("expected nested node to be converted, got %#v", nested[0])
+	}
+}
+
+func TestV5TxConfigurers(t *testing.T) {
+	if configurers := v5TxConfigurers(nil); len(configurers) != 0 {
+		t.Errorf("expected no configurers for nil config")
+	}
+
+	var config neo4j.TransactionConfig
+	metadata := map[string]interface{}{"app": "test"}
+	for _, configure := range v5TxConfigurers(&txConfig{timeout: time.Second, metadata: metadata}) {
+		configure(&config)
+	}
+	if config.Timeout != time.Second || !reflect.DeepEqual(config.Metadata, metadata) {
+		t.Errorf("unexpected transaction config: %+v", config)
+	}
+}
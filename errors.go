@@ -1,5 +1,29 @@
 package neo4jorm
 
+import (
+	"errors"
+	"fmt"
+)
+
 const (
 	ErrInvalidModel = "invalid model"
 )
+
+var errManagedTx = errors.New("transaction is managed by Client.Transaction and can not be committed or rolled back manually")
+
+// RelationGroupError 批量写入或删除关系时，某一分组执行失败
+type RelationGroupError struct {
+	Start string // 起始节点标签
+	End   string // 目标节点标签
+	Type  string // 关系类型
+	Count int    // 该分组的关系数量
+	Err   error
+}
+
+func (e *RelationGroupError) Error() string {
+	return fmt.Sprintf("relations (:%s)-[:%s]->(:%s) x%d failed: %v", e.Start, e.Type, e.End, e.Count, e.Err)
+}
+
+func (e *RelationGroupError) Unwrap() error {
+	return e.Err
+}
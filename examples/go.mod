@@ -6,5 +6,5 @@ replace github.com/chengjiahua/neo4jorm => ../
 
 require (
 	github.com/chengjiahua/neo4jorm v0.0.0-20250301100008-58121db791fa // indirect
-	github.com/neo4j/neo4j-go-driver/v4 v4.4.7 // indirect
+	github.com/neo4j/neo4j-go-driver/v5 v5.28.4 // indirect
 )
@@ -78,7 +78,7 @@ func basicExample(orm *neo4jorm.Client) error {
 		panic(err)
 	}
 
-	// 每个Relation的start表都是table1，end表都是table2
+	// 同一批中可以混合不同起止节点类型和关系类型，按组分别执行
 	err = ProductOrm.DebugInfo().CreateRelations([]neo4jorm.Relation{
 		{Start: &Product{SKU: "P1001"}, End: &Product{SKU: "P1002"}},
 	}, "RELATION")
@@ -124,8 +124,8 @@ func relationshipExample(orm *neo4jorm.Client) error {
 	}
 
 	type User struct {
-		ID   string `neo4j:"id,primary,label=User"`
-		Name string `neo4j:"name"`
+		ID   string `neo4j:"name=id,primary,label=User"`
+		Name string `neo4j:"name=name"`
 		// 1:1 关系
 		Manager *User `neo4j:"rel=REPORTS_TO,direction=outgoing,merge=true"`
 		// 1:N 关系
@@ -148,7 +148,8 @@ func relationshipExample(orm *neo4jorm.Client) error {
 		Projects: []*Project{project},
 	}
 
-	// 自动合并节点和关系
+	// 自动合并节点和关系：先合并 user，再合并 Manager、Friends、Projects 中的节点，
+	// 最后写入 REPORTS_TO、FRIENDS、OWNS 关系，全部在同一事务中完成
 	err := orm.Model(&User{}).MergeBatch([]*User{user})
 	if err != nil {
 		panic(err)
@@ -0,0 +1,125 @@
+package neo4jorm
+
+import (
+	"context"
+)
+
+// fakeDriver 按查询语句返回预设记录的驱动，用于不连接数据库测试模型方法
+type fakeDriver struct {
+	graphDriver
+	queries  []string // 在事务之外执行的语句
+	sessions []*fakeSession
+	results  func(query string) []*record
+	ctx      context.Context // 最近一次在事务之外执行语句的上下文
+	write    bool            // 最近一次 executeQuery 是否路由到写节点
+}
+
+// newFakeDriver 创建每条语句都返回 props 对应节点的驱动
+func newFakeDriver(props ...map[string]interface{}) *fakeDriver {
+	return &fakeDriver{results: func(string) []*record {
+		records := make([]*record, len(props))
+		for i, p := range props {
+			records[i] = &record{values: []interface{}{&graphNode{props: p}}}
+		}
+		return records
+	}}
+}
+
+// lastSession 返回最近创建的会话
+func (d *fakeDriver) lastSession() *fakeSession {
+	return d.sessions[len(d.sessions)-1]
+}
+
+func (d *fakeDriver) newSession(ctx context.Context, database string) graphSession {
+	s := &fakeSession{driver: d}
+	d.sessions = append(d.sessions, s)
+	return s
+}
+
+func (d *fakeDriver) executeQuery(ctx context.Context, database string, query string, params map[string]interface{}, write bool, config *txConfig) ([]*record, error) {
+	d.queries = append(d.queries, query)
+	d.ctx = ctx
+	d.write = write
+	return d.results(query), nil
+}
+
+// fakeSession 记录开启的事务和关闭状态的会话
+type fakeSession struct {
+	graphSession
+	driver *fakeDriver
+	txs    []*fakeTx
+	closed bool
+}
+
+func (s *fakeSession) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	s.driver.queries = append(s.driver.queries, query)
+	s.driver.ctx = ctx
+	return &fakeResult{records: s.driver.results(query)}, nil
+}
+
+func (s *fakeSession) beginTransaction(ctx context.Context, config *txConfig) (graphExplicitTx, error) {
+	tx := &fakeTx{session: s, ctx: ctx}
+	s.txs = append(s.txs, tx)
+	return tx, nil
+}
+
+// executeWrite 与驱动的托管事务一致：work返回nil时提交，否则回滚
+func (s *fakeSession) executeWrite(ctx context.Context, work func(tx graphTx) error, config *txConfig) error {
+	tx := &fakeTx{session: s, ctx: ctx}
+	s.txs = append(s.txs, tx)
+	if err := work(tx); err != nil {
+		tx.rolledBack = true
+		return err
+	}
+	tx.committed = true
+	return nil
+}
+
+func (s *fakeSession) close(ctx context.Context) error {
+	s.closed = true
+	return nil
+}
+
+// fakeTx 记录执行的语句以及提交、回滚状态的事务
+type fakeTx struct {
+	session    *fakeSession
+	ctx        context.Context // 开启事务时的上下文
+	runCtx     context.Context // 最近一次执行语句的上下文
+	queries    []string
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) run(ctx context.Context, query string, params map[string]interface{}) (graphResult, error) {
+	t.queries = append(t.queries, query)
+	t.runCtx = ctx
+	return &fakeResult{records: t.session.driver.results(query)}, nil
+}
+
+func (t *fakeTx) commit(ctx context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) rollback(ctx context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+// fakeResult 逐条返回预设记录的结果
+type fakeResult struct {
+	records []*record
+	current *record
+}
+
+func (r *fakeResult) next(ctx context.Context) bool {
+	if len(r.records) == 0 {
+		return false
+	}
+	r.current, r.records = r.records[0], r.records[1:]
+	return true
+}
+
+func (r *fakeResult) record() *record                   { return r.current }
+func (r *fakeResult) err() error                        { return nil }
+func (r *fakeResult) consume(ctx context.Context) error { r.records = nil; return nil }
@@ -2,4 +2,7 @@ module neo4jorm
 
 go 1.24.0
 
-require github.com/neo4j/neo4j-go-driver/v4 v4.4.7 // indirect
+require (
+	github.com/neo4j/neo4j-go-driver/v4 v4.4.7
+	github.com/neo4j/neo4j-go-driver/v5 v5.28.4
+)
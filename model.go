@@ -1,8 +1,11 @@
 package neo4jorm
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -18,18 +21,36 @@ type Registry struct {
 type Model struct {
 	debug      bool
 	client     *Client
+	tx         *Transaction    // 绑定的用户事务，为空时每次操作使用独立会话
+	ctx        context.Context // 查询上下文，用于取消查询和控制超时
 	modelType  reflect.Type
 	elemType   reflect.Type // 新增字段，保存切片元素类型
 	table      string
 	primaryKey string
 	fieldMap   map[string]string
 	generated  map[string]bool
+	relations  map[string]RelationshipConfig // 关系字段，键为结构体字段名
+	relFields  []string                      // 关系字段按声明顺序排列
+	startField string                        // 关系实体的起始节点字段，非空时table为关系类型
+	endField   string                        // 关系实体的目标节点字段
 
 	//查询参数
 	conditions []string               // 存储WHERE条件表达式
 	params     map[string]interface{} // 查询参数
+	paramSeq   int                    // 已生成的参数数量，用于生成唯一参数名
 	orderBy    []string               // 排序字段
 	limit      int                    // 限制结果数量
+	skip       int                    // 跳过的结果数量
+	keyset     bool                   // 是否使用游标分页
+	after      string                 // 游标分页的起始游标
+	cursor     string                 // 最近一次游标分页查询的下一页游标，不随查询条件清理
+	preloads   []string               // 预加载的关系字段
+	selects    []string               // Select 指定的返回列
+	groupBy    []string               // GroupBy 指定的分组字段
+	hops       []traverseHop          // 关系遍历
+	target     *Model                 // 遍历查询的目标模型，由结果类型决定
+	err        error                  // 构建查询时产生的错误，由查询方法返回
+	invalid    error                  // 模型不是结构体时的错误，清理查询状态后仍然保留
 }
 
 func (m *Model) register() error {
@@ -51,47 +72,143 @@ func getModel(obj interface{}) (*Model, bool) {
 func newModel(client *Client, model interface{}) *Model {
 	m, ok := getModel(model)
 	if ok {
+		// 注册表中的模型可能来自其他客户端
+		m.client = client
 		return m
 	}
 
+	m = parseModel(client, model)
+	// 不是结构体时不注册，由查询和写入方法返回错误
+	if m.invalid != nil {
+		return m
+	}
+	m.register()
+
+	if m.debug {
+		m.DebugInfo()
+	}
+	// 返回副本，避免查询条件、预加载等状态写入注册表中的模型
+	return m.clone()
+}
+
+// parseModel 解析模型的类型和标签，不写入注册表
+func parseModel(client *Client, model interface{}) *Model {
 	modelType := reflect.TypeOf(model)
 	if modelType.Kind() == reflect.Ptr {
 		modelType = modelType.Elem()
 	}
+	if modelType.Kind() != reflect.Struct {
+		err := fmt.Errorf("%s: model must be a struct or a pointer to a struct, got %T", ErrInvalidModel, model)
+		return &Model{
+			client:    client,
+			debug:     client.debug,
+			modelType: modelType,
+			elemType:  modelType,
+			fieldMap:  make(map[string]string),
+			generated: make(map[string]bool),
+			relations: make(map[string]RelationshipConfig),
+			err:       err,
+			invalid:   err,
+		}
+	}
 
-	m = &Model{
+	m := &Model{
 		client:    client,
 		debug:     client.debug,
 		modelType: modelType,
 		elemType:  modelType, // 保存切片元素类型
 		fieldMap:  make(map[string]string),
 		generated: make(map[string]bool),
+		relations: make(map[string]RelationshipConfig),
 	}
-
 	m.parseTags()
-	m.register()
+	return m
+}
 
-	if m.debug {
-		m.DebugInfo()
+// resultModel 获取映射查询结果的结构体的模型：已注册时使用注册的模型，否则只解析不注册，
+// 避免 Scan 的目标结构体参与按标签查找模型
+func resultModel(client *Client, t reflect.Type) *Model {
+	zero := reflect.New(t).Elem().Interface()
+	if m, ok := getModel(zero); ok {
+		return m
 	}
-	return m
+	return parseModel(client, zero)
 }
 
+// clone 复制模型，查询条件和参数也一并复制，副本上继续添加条件不会影响原模型
 func (m *Model) clone() *Model {
+	var params map[string]interface{}
+	if m.params != nil {
+		params = make(map[string]interface{}, len(m.params))
+		for k, v := range m.params {
+			params[k] = v
+		}
+	}
 	return &Model{
 		debug:      m.debug,
 		client:     m.client,
+		tx:         m.tx,
+		ctx:        m.ctx,
 		modelType:  m.modelType,
 		elemType:   m.elemType,
 		table:      m.table,
 		primaryKey: m.primaryKey,
 		fieldMap:   m.fieldMap,
 		generated:  m.generated,
-		conditions: m.conditions,
-		params:     m.params,
-		orderBy:    m.orderBy,
+		relations:  m.relations,
+		relFields:  m.relFields,
+		startField: m.startField,
+		endField:   m.endField,
+		conditions: append([]string(nil), m.conditions...),
+		params:     params,
+		paramSeq:   m.paramSeq,
+		orderBy:    append([]string(nil), m.orderBy...),
 		limit:      m.limit,
+		skip:       m.skip,
+		keyset:     m.keyset,
+		after:      m.after,
+		preloads:   append([]string(nil), m.preloads...),
+		selects:    append([]string(nil), m.selects...),
+		groupBy:    append([]string(nil), m.groupBy...),
+		hops:       append([]traverseHop(nil), m.hops...),
+		target:     m.target,
+		err:        m.err,
+		invalid:    m.invalid,
+	}
+}
+
+// WithTx 返回绑定到指定事务的模型副本，tx为nil时解除绑定
+func (m *Model) WithTx(tx *Transaction) *Model {
+	c := m.clone()
+	c.tx = tx
+	return c
+}
+
+// WithContext 返回使用指定上下文的模型副本，ctx取消或超时时正在执行的查询会被中止
+func (m *Model) WithContext(ctx context.Context) *Model {
+	c := m.clone()
+	c.ctx = ctx
+	return c
+}
+
+// withCtx 临时使用ctx执行fn，结束后恢复原来的上下文。与 WithContext 不同，
+// 查询条件的清理和游标都作用在m本身
+func (m *Model) withCtx(ctx context.Context, fn func() error) error {
+	prev := m.ctx
+	m.ctx = ctx
+	defer func() { m.ctx = prev }()
+	return fn()
+}
+
+// context 获取本次操作使用的上下文，依次取模型上下文、事务上下文和默认上下文
+func (m *Model) context() context.Context {
+	if m.ctx != nil {
+		return m.ctx
+	}
+	if m.tx != nil && m.tx.ctx != nil {
+		return m.tx.ctx
 	}
+	return context.Background()
 }
 
 func (m *Model) setDebug(debug bool) {
@@ -99,6 +216,7 @@ func (m *Model) setDebug(debug bool) {
 }
 
 func (m *Model) parseTags() {
+	entity := isRelationshipEntity(m.modelType)
 	for i := 0; i < m.modelType.NumField(); i++ {
 		field := m.modelType.Field(i)
 		tag := field.Tag.Get(tagName)
@@ -107,10 +225,41 @@ func (m *Model) parseTags() {
 		}
 
 		tags := parseTag(tag)
+		// 关系实体的 rel 标签声明关系类型，起止节点字段不是关系属性
+		if entity {
+			if relType, ok := tags[tagRel]; ok {
+				m.table = relType
+			}
+			if isStartField(field, tags) {
+				m.startField = field.Name
+				continue
+			}
+			if isEndField(field, tags) {
+				m.endField = field.Name
+				continue
+			}
+		} else if relType, ok := tags[tagRel]; ok {
+			// 关系字段不是节点属性，只记录关系配置
+			direction := tags[tagDirection]
+			if direction == "" {
+				direction = Outgoing
+			}
+			m.relations[field.Name] = RelationshipConfig{
+				Type:      relType,
+				Direction: direction,
+				Merge:     tags[tagMerge] == "true",
+			}
+			m.relFields = append(m.relFields, field.Name)
+			continue
+		}
+
 		// 处理标签
 		if table, ok := tags[tagTable]; ok {
 			m.table = table
 		}
+		if label, ok := tags[tagLabel]; ok {
+			m.table = label
+		}
 		if _, ok := tags[tagPrimary]; ok {
 			m.primaryKey = field.Name
 		}
@@ -120,13 +269,113 @@ func (m *Model) parseTags() {
 
 		// 处理属性名称映射
 		propName := field.Name
-		if name, ok := tags[tagkey]; ok {
+		if name, ok := tags[tagkey]; ok && name != "" {
 			propName = name
 		}
 		m.fieldMap[field.Name] = propName
 	}
 }
 
+// isRelationshipEntity 判断结构体是否为关系实体，即同时包含起始节点和目标节点字段
+func isRelationshipEntity(t reflect.Type) bool {
+	var hasStart, hasEnd bool
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tags := parseTag(field.Tag.Get(tagName))
+		hasStart = hasStart || isStartField(field, tags)
+		hasEnd = hasEnd || isEndField(field, tags)
+	}
+	return hasStart && hasEnd
+}
+
+// isStartField 起始节点字段：名为 StartNode 或带 start 标签
+func isStartField(field reflect.StructField, tags map[string]string) bool {
+	_, ok := tags[tagStart]
+	return ok || field.Name == "StartNode"
+}
+
+// isEndField 目标节点字段：名为 EndNode 或带 end 标签
+func isEndField(field reflect.StructField, tags map[string]string) bool {
+	_, ok := tags[tagEnd]
+	return ok || field.Name == "EndNode"
+}
+
+// isRelationship 是否为关系实体模型
+func (m *Model) isRelationship() bool {
+	return m.startField != ""
+}
+
+// modelByLabel 在已注册模型中查找标签（关系实体为关系类型）对应的模型，没有时返回nil。
+// 多个模型使用同一标签时无法确定映射的类型，返回错误
+func modelByLabel(label string, relationship bool) (*Model, error) {
+	var found []*Model
+	modelRegistry.models.Range(func(_, value interface{}) bool {
+		m := value.(*Model)
+		if m.table == label && m.isRelationship() == relationship {
+			found = append(found, m)
+		}
+		return true
+	})
+	if len(found) > 1 {
+		types := make([]string, len(found))
+		for i, m := range found {
+			types[i] = m.modelType.String()
+		}
+		sort.Strings(types)
+		return nil, fmt.Errorf("%s: label %s is used by models %s", ErrInvalidModel, label, strings.Join(types, ", "))
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	return found[0], nil
+}
+
+// propertyName 将结构体字段名解析为属性名，也接受映射后的属性名
+func (m *Model) propertyName(field string) (string, error) {
+	if prop, ok := m.fieldMap[field]; ok {
+		return prop, nil
+	}
+	for _, prop := range m.fieldMap {
+		if prop == field {
+			return prop, nil
+		}
+	}
+	return "", fmt.Errorf("%s: %s has no property field %s", ErrInvalidModel, m.modelType, field)
+}
+
+// fieldName 将属性名解析为结构体字段名
+func (m *Model) fieldName(prop string) string {
+	for field, p := range m.fieldMap {
+		if p == prop {
+			return field
+		}
+	}
+	return ""
+}
+
+// setErr 记录构建查询时的错误，只保留第一个错误
+func (m *Model) setErr(err error) {
+	if m.err == nil {
+		m.err = err
+	}
+}
+
+// takeErr 返回构建查询时记录的错误并清理查询状态。写入方法不经过 cleanQuery，
+// 在执行前调用它，避免链式条件上的错误被忽略或遗留到之后的查询
+func (m *Model) takeErr() error {
+	err := m.err
+	if err != nil {
+		m.cleanQuery()
+	}
+	return err
+}
+
+// isPropertyField 字段是否映射为属性，关系字段和关系实体的起止节点字段不是属性
+func (m *Model) isPropertyField(field string) bool {
+	_, ok := m.fieldMap[field]
+	return ok
+}
+
 // 在model.go中添加调试方法
 func (m *Model) DebugInfo() *Model {
 	m.setDebug(true)
@@ -138,10 +387,13 @@ func (m *Model) DebugInfo() *Model {
 			" primaryKey:%s"+
 			" fieldMap:%v"+
 			" generated:%v"+
+			" relations:%v"+
 			" conditions:%v"+
 			" params:%v"+
 			" orderBy:%v"+
 			" limit:%v"+
+			" skip:%v"+
+			" preloads:%v"+
 			"}",
 		m.modelType.String(),
 		m.elemType.String(),
@@ -149,10 +401,13 @@ func (m *Model) DebugInfo() *Model {
 		m.primaryKey,
 		m.fieldMap,
 		m.generated,
+		m.relations,
 		m.conditions,
 		m.params,
 		m.orderBy,
 		m.limit,
+		m.skip,
+		m.preloads,
 	))
 	return m
 }
@@ -0,0 +1,284 @@
+package neo4jorm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Page 分页查询结果
+type Page struct {
+	Items   interface{} // 当前页的结果，与传入 Paginate 的切片类型相同
+	Total   int64       // 满足条件的结果总数
+	Page    int         // 当前页码，从1开始
+	Size    int         // 每页数量
+	HasNext bool        // 是否还有下一页
+}
+
+// Paginate 分页查询，page从1开始。先用相同的条件统计总数，再查询当前页并写入out
+func (m *Model) Paginate(page, size int, out interface{}) (*Page, error) {
+	if size <= 0 {
+		m.cleanQuery()
+		return nil, fmt.Errorf("paginate: invalid page size %d", size)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	m.bindTarget(out)
+	total, err := m.count()
+	if err != nil {
+		m.cleanQuery()
+		return nil, err
+	}
+
+	if err := m.Offset((page - 1) * size).Limit(size).Find(out); err != nil {
+		return nil, err
+	}
+	return &Page{
+		Items:   reflect.ValueOf(out).Elem().Interface(),
+		Total:   total,
+		Page:    page,
+		Size:    size,
+		HasNext: int64(page*size) < total,
+	}, nil
+}
+
+// FindInBatches 按主键顺序分批查询，每批最多size条结果写入out后调用fn，batch为从1开始的批次号。
+// 批次之间使用游标分页，已设置的排序和数量限制不生效；fn返回错误时停止查询并返回该错误
+func (m *Model) FindInBatches(out interface{}, size int, fn func(batch int) error) error {
+	if size <= 0 {
+		m.cleanQuery()
+		return fmt.Errorf("find in batches: invalid batch size %d", size)
+	}
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.Elem().Kind() != reflect.Slice {
+		m.cleanQuery()
+		return errors.New("results must be a pointer to a slice")
+	}
+
+	// 每批都在查询条件的副本上执行，Find 会清理副本的条件
+	base := m.clone()
+	base.orderBy, base.limit, base.skip = nil, 0, 0
+	m.cleanQuery()
+
+	cursor := ""
+	for batch := 1; ; batch++ {
+		q := base.clone()
+		outVal.Elem().Set(reflect.Zero(outVal.Elem().Type()))
+		if err := q.After(cursor).Limit(size).Find(out); err != nil {
+			return err
+		}
+		if outVal.Elem().Len() == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if cursor = q.Cursor(); cursor == "" {
+			return nil
+		}
+	}
+}
+
+// countQuery 构建统计数量的查询，与 buildQuery 使用相同的条件和遍历，遍历查询统计去重后的终点节点
+func (m *Model) countQuery() string {
+	if len(m.hops) > 0 {
+		return m.buildMatch() + fmt.Sprintf(" RETURN count(DISTINCT %s)", m.traverseVar())
+	}
+	return m.buildMatch() + " RETURN count(n)"
+}
+
+// count 统计满足当前条件的结果数量，不清理查询条件
+func (m *Model) count() (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	query := m.countQuery()
+	if m.debug {
+		fmt.Printf("Executing Count:\n%s\nWith params: %+v\n", query, m.params)
+	}
+
+	records, err := m.query(query, m.params)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, errors.New("count query returned no records")
+	}
+	total, ok := convertToInt(reflect.ValueOf(records[0].values[0]))
+	if !ok {
+		return 0, fmt.Errorf("count query returned %T", records[0].values[0])
+	}
+	return total, nil
+}
+
+// keysetKey 游标分页的排序键
+type keysetKey struct {
+	field string // 结构体字段名
+	prop  string // 属性名
+	desc  bool
+}
+
+// keysetCursor 游标的内容，记录排序键和最后一行的键值，解码时校验排序与当前查询一致
+type keysetCursor struct {
+	Order  []string      `json:"o"`
+	Values []interface{} `json:"v"`
+}
+
+// After 按游标分页，cursor为上一页 Find 之后 Cursor 返回的值，为空时查询第一页。
+// 排序项只能是属性字段，主键会作为最后一个排序键保证顺序稳定，如
+// m.OrderBy("Price DESC").After(cursor).Limit(20).Find(&products)
+func (m *Model) After(cursor string) *Model {
+	m.keyset = true
+	m.after = cursor
+	return m
+}
+
+// Cursor 返回最近一次使用 After 的 Find 结果中最后一行的游标，用于查询下一页；
+// 结果少于 Limit（没有下一页）时返回空字符串
+func (m *Model) Cursor() string {
+	return m.cursor
+}
+
+// keysetKeys 解析游标分页的排序键，并在末尾补充主键
+func (m *Model) keysetKeys() ([]keysetKey, error) {
+	if m.primaryKey == "" {
+		return nil, errors.New("keyset pagination requires a primary key")
+	}
+	if len(m.hops) > 0 || m.isRelationship() {
+		return nil, fmt.Errorf("%s: keyset pagination is only supported on node queries", ErrInvalidModel)
+	}
+
+	var keys []keysetKey
+	hasPrimary := false
+	for _, item := range m.orderBy {
+		expr, direction := splitDirection(item)
+		if expr == "" {
+			continue
+		}
+		prop, err := m.propertyName(expr)
+		if err != nil {
+			return nil, fmt.Errorf("keyset pagination: order by %q is not a property field", item)
+		}
+		field := m.fieldName(prop)
+		hasPrimary = hasPrimary || field == m.primaryKey
+		keys = append(keys, keysetKey{field: field, prop: prop, desc: strings.HasPrefix(direction, "DESC")})
+	}
+	if !hasPrimary {
+		keys = append(keys, keysetKey{field: m.primaryKey, prop: m.fieldMap[m.primaryKey]})
+	}
+	return keys, nil
+}
+
+// applyKeyset 将排序替换为游标排序键，并按游标添加 (k1 > v1) OR (k1 = v1 AND k2 > v2) ... 形式的条件
+func (m *Model) applyKeyset() []keysetKey {
+	if !m.keyset {
+		return nil
+	}
+	keys, err := m.keysetKeys()
+	if err != nil {
+		m.setErr(err)
+		return nil
+	}
+
+	order := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.desc {
+			order = append(order, Desc(key.field))
+		} else {
+			order = append(order, key.field)
+		}
+	}
+	m.orderBy = order
+	// 只返回部分属性时补充排序键，生成游标需要读取最后一行的排序键
+	if len(m.selects) > 0 {
+		for _, key := range keys {
+			if _, ok := m.selectedAlias(key.prop); !ok {
+				m.selects = append(m.selects, key.field)
+			}
+		}
+	}
+
+	if m.after == "" {
+		return keys
+	}
+	values, err := decodeCursor(m.after, order)
+	if err != nil {
+		m.setErr(err)
+		return nil
+	}
+
+	params := make([]string, len(keys))
+	for i, key := range keys {
+		value, err := m.propertyValue(key.prop, values[i])
+		if err != nil {
+			m.setErr(err)
+			return nil
+		}
+		params[i] = m.addParam(key.prop, value)
+	}
+	branches := make([]string, 0, len(keys))
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("n.%s = $%s", keys[j].prop, params[j]))
+		}
+		op := ">"
+		if key.desc {
+			op = "<"
+		}
+		parts = append(parts, fmt.Sprintf("n.%s %s $%s", key.prop, op, params[i]))
+		branches = append(branches, "("+strings.Join(parts, " AND ")+")")
+	}
+	m.conditions = append(m.conditions, "("+strings.Join(branches, " OR ")+")")
+	return keys
+}
+
+// encodeCursor 由结果中的一行生成游标
+func encodeCursor(keys []keysetKey, order []string, row reflect.Value) (string, error) {
+	if row.Kind() == reflect.Ptr {
+		row = row.Elem()
+	}
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i] = row.FieldByName(key.field).Interface()
+	}
+	data, err := json.Marshal(keysetCursor{Order: order, Values: values})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor 解析游标，整数保持为int64，排序与生成游标时不一致时返回错误
+func decodeCursor(cursor string, order []string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c keysetCursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if !reflect.DeepEqual(c.Order, order) || len(c.Values) != len(order) {
+		return nil, fmt.Errorf("cursor was created for order %v, not %v", c.Order, order)
+	}
+
+	for i, v := range c.Values {
+		if n, ok := v.(json.Number); ok {
+			if iv, err := n.Int64(); err == nil {
+				c.Values[i] = iv
+			} else if fv, err := n.Float64(); err == nil {
+				c.Values[i] = fv
+			}
+		}
+	}
+	return c.Values, nil
+}
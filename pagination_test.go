@@ -0,0 +1,144 @@
+package neo4jorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		if strings.Contains(query, "count(n)") {
+			return []*record{{values: []interface{}{int64(5)}}}
+		}
+		return []*record{
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "C", "price": 3.0}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "D", "price": 4.0}}}},
+		}
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+
+	var products []condTestProduct
+	page, err := m.Where(Gt("Price", 1)).OrderBy("SKU").Paginate(2, 2, &products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 5 || page.Page != 2 || page.Size != 2 || !page.HasNext {
+		t.Errorf("unexpected page: %+v", page)
+	}
+	if items := page.Items.([]condTestProduct); len(items) != 2 || items[1].SKU != "D" {
+		t.Errorf("unexpected items: %+v", page.Items)
+	}
+
+	expected := []string{
+		"MATCH (n:Product) WHERE n.price > $price_0 RETURN count(n)",
+		"MATCH (n:Product) WHERE n.price > $price_0 RETURN n  ORDER BY n.sku SKIP 2 LIMIT 2",
+	}
+	if len(driver.queries) != 2 || driver.queries[0] != expected[0] || driver.queries[1] != expected[1] {
+		t.Errorf("expected queries %q, got %q", expected, driver.queries)
+	}
+
+	if page, err := m.Paginate(3, 2, &[]condTestProduct{}); err != nil || page.HasNext {
+		t.Errorf("expected last page, got %+v, %v", page, err)
+	}
+	if len(m.conditions) != 0 || m.skip != 0 || m.limit != 0 {
+		t.Errorf("expected query state to be cleaned")
+	}
+}
+
+func TestKeysetPagination(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		return []*record{
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "A", "price": 9.5}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "B", "price": int64(8)}}}},
+		}
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+
+	var first []condTestProduct
+	if err := m.OrderBy("Price DESC").After("").Limit(2).Find(&first); err != nil {
+		t.Fatal(err)
+	}
+	cursor := m.Cursor()
+	if cursor == "" {
+		t.Fatal("expected cursor for a full page")
+	}
+
+	var second []*condTestProduct
+	if err := m.Where(Gt("Price", 1)).OrderBy("Price DESC").After(cursor).Limit(2).Find(&second); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"MATCH (n:Product) RETURN n  ORDER BY n.price DESC, n.sku LIMIT 2",
+		"MATCH (n:Product) WHERE n.price > $price_0 AND ((n.price < $price_1) OR (n.price = $price_1 AND n.sku > $sku_2)) " +
+			"RETURN n  ORDER BY n.price DESC, n.sku LIMIT 2",
+	}
+	if len(driver.queries) != 2 || driver.queries[0] != expected[0] || driver.queries[1] != expected[1] {
+		t.Errorf("expected queries %q, got %q", expected, driver.queries)
+	}
+
+	if err := m.OrderBy("Price").After(cursor).Limit(2).Find(&second); err == nil {
+		t.Errorf("expected error for a cursor created with a different order")
+	}
+	if err := m.OrderBy("size(Name)").After("").Find(&second); err == nil {
+		t.Errorf("expected error when ordering by an expression")
+	}
+
+	values, err := decodeCursor(cursor, []string{"Price DESC", "SKU"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0] != int64(8) || values[1] != "B" {
+		t.Errorf("unexpected cursor values: %#v", values)
+	}
+}
+
+func TestFindInBatches(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		if strings.Contains(query, "$sku") {
+			return []*record{{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "C"}}}}}
+		}
+		return []*record{
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "A"}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "B"}}}},
+		}
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+
+	var batch []condTestProduct
+	var skus []string
+	err := m.Where(Gt("Price", 1)).OrderBy("Price").Limit(10).FindInBatches(&batch, 2, func(n int) error {
+		for _, p := range batch {
+			skus = append(skus, fmt.Sprintf("%d:%s", n, p.SKU))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(skus, []string{"1:A", "1:B", "2:C"}) {
+		t.Errorf("unexpected batches: %v", skus)
+	}
+	expected := []string{
+		"MATCH (n:Product) WHERE n.price > $price_0 RETURN n  ORDER BY n.sku LIMIT 2",
+		"MATCH (n:Product) WHERE n.price > $price_0 AND ((n.sku > $sku_1)) RETURN n  ORDER BY n.sku LIMIT 2",
+	}
+	if !reflect.DeepEqual(driver.queries, expected) {
+		t.Errorf("expected queries %q, got %q", expected, driver.queries)
+	}
+	if len(m.conditions) != 0 {
+		t.Errorf("expected query state to be cleaned")
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = m.FindInBatches(&batch, 2, func(int) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected the callback error to stop iteration, got %v after %d calls", err, calls)
+	}
+}
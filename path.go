@@ -0,0 +1,237 @@
+package neo4jorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Path 路径查询结果，节点和关系按路径顺序排列。
+// 标签（关系为关系类型）已注册为模型时元素为对应结构体指针，否则为 *Node 或 *Edge
+type Path struct {
+	Nodes         []interface{}
+	Relationships []interface{}
+}
+
+// Node 未注册模型的节点
+type Node struct {
+	ElementID string
+	Labels    []string
+	Props     map[string]interface{}
+}
+
+// Edge 未注册模型的关系
+type Edge struct {
+	ElementID string
+	StartID   string
+	EndID     string
+	Type      string
+	Props     map[string]interface{}
+}
+
+// traverseHop 一段关系遍历
+type traverseHop struct {
+	relType   string
+	direction string
+	minDepth  int
+	maxDepth  int
+}
+
+// Traverse 从查询条件匹配的节点出发沿关系遍历，多次调用时依次连接成一条路径。
+// depth省略时遍历一跳，一个值为固定跳数，两个值为跳数范围，如 Traverse("FRIENDS", Outgoing, 1, 3)。
+// 之后调用 Find 返回路径终点的节点，调用 FindPaths 返回完整路径
+func (m *Model) Traverse(relType, direction string, depth ...int) *Model {
+	hop := traverseHop{relType: relType, direction: direction, minDepth: 1, maxDepth: 1}
+	switch len(depth) {
+	case 0:
+	case 1:
+		hop.minDepth, hop.maxDepth = depth[0], depth[0]
+	case 2:
+		hop.minDepth, hop.maxDepth = depth[0], depth[1]
+	default:
+		m.setErr(fmt.Errorf("traverse %s: expected at most 2 depth values, got %d", relType, len(depth)))
+		return m
+	}
+
+	switch {
+	case m.isRelationship():
+		m.setErr(fmt.Errorf("%s: cannot traverse from relationship model %s", ErrInvalidModel, m.modelType))
+	case relType == "":
+		m.setErr(errors.New("traverse: relationship type is required"))
+	case !validDirection(direction):
+		m.setErr(fmt.Errorf("traverse %s: invalid direction %q", relType, direction))
+	case hop.minDepth < 0 || hop.maxDepth < 1 || hop.minDepth > hop.maxDepth:
+		m.setErr(fmt.Errorf("traverse %s: invalid depth %d..%d", relType, hop.minDepth, hop.maxDepth))
+	default:
+		m.hops = append(m.hops, hop)
+	}
+	return m
+}
+
+// bindTarget 遍历查询时根据结果类型确定终点节点的模型，out为结构体指针或结构体切片指针
+func (m *Model) bindTarget(out interface{}) {
+	if len(m.hops) == 0 {
+		return
+	}
+	t := reflect.TypeOf(out)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return
+	}
+	t = t.Elem()
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	target := newModel(m.client, reflect.New(t).Elem().Interface())
+	if target.isRelationship() || target.table == "" {
+		m.setErr(fmt.Errorf("%s: traverse target %s is not a labeled node model", ErrInvalidModel, t))
+		return
+	}
+	m.target = target
+}
+
+// traverseVar 遍历终点节点的变量名
+func (m *Model) traverseVar() string {
+	return fmt.Sprintf("t%d", len(m.hops)-1)
+}
+
+// buildTraverse 生成遍历的路径模式，如 MATCH p = (n)-[:FRIENDS*1..3]->(t0:User)，
+// 每段终点依次命名为 t0、t1...，确定了目标模型时为最后一个节点加上其标签
+func (m *Model) buildTraverse() string {
+	var sb strings.Builder
+	sb.WriteString("MATCH p = (n)")
+	for i, hop := range m.hops {
+		relType := hop.relType
+		switch {
+		case hop.minDepth != hop.maxDepth:
+			relType += fmt.Sprintf("*%d..%d", hop.minDepth, hop.maxDepth)
+		case hop.maxDepth != 1:
+			relType += fmt.Sprintf("*%d", hop.maxDepth)
+		}
+		sb.WriteString(relPattern("", relType, hop.direction))
+		sb.WriteString(fmt.Sprintf("(t%d", i))
+		if i == len(m.hops)-1 && m.target != nil {
+			sb.WriteString(":" + m.target.table)
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// FindPaths 查询 Traverse 描述的全部路径
+func (m *Model) FindPaths(paths *[]Path) error {
+	defer m.cleanQuery()
+	if len(m.hops) == 0 && m.err == nil {
+		return errors.New("FindPaths requires at least one Traverse")
+	}
+
+	query := m.buildQueryReturning("p")
+	if m.err != nil {
+		return m.err
+	}
+	if m.debug {
+		fmt.Printf("Executing Query:\n%s\nWith params: %+v\n", query, m.params)
+	}
+
+	records, err := m.query(query, m.params)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		gp, ok := rec.values[0].(*graphPath)
+		if !ok {
+			return errors.New("query did not return a path")
+		}
+		path, err := toPath(gp)
+		if err != nil {
+			return err
+		}
+		*paths = append(*paths, path)
+	}
+	return nil
+}
+
+// toPath 将驱动返回的路径映射为 Path，节点和关系按标签或关系类型映射到已注册模型
+func toPath(gp *graphPath) (Path, error) {
+	path := Path{
+		Nodes:         make([]interface{}, 0, len(gp.nodes)),
+		Relationships: make([]interface{}, 0, len(gp.relationships)),
+	}
+
+	nodes := make(map[string]interface{}, len(gp.nodes))
+	for _, n := range gp.nodes {
+		node, err := toNode(n)
+		if err != nil {
+			return Path{}, err
+		}
+		nodes[n.id] = node
+		path.Nodes = append(path.Nodes, node)
+	}
+
+	for _, r := range gp.relationships {
+		rel, err := toRelationship(r, nodes)
+		if err != nil {
+			return Path{}, err
+		}
+		path.Relationships = append(path.Relationships, rel)
+	}
+	return path, nil
+}
+
+// toNode 按节点的第一个已注册标签映射为模型结构体指针，没有已注册标签时返回 *Node
+func toNode(n *graphNode) (interface{}, error) {
+	for _, label := range n.labels {
+		m, err := modelByLabel(label, false)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			continue
+		}
+		elem := reflect.New(m.modelType)
+		if err := m.mapToStruct(n.props, elem.Interface()); err != nil {
+			return nil, err
+		}
+		return elem.Interface(), nil
+	}
+	return &Node{ElementID: n.id, Labels: n.labels, Props: n.props}, nil
+}
+
+// toRelationship 按关系类型映射为已注册的关系实体，起止节点类型与实体字段一致时一并填充；
+// 关系类型未注册时返回 *Edge
+func toRelationship(r *graphRelationship, nodes map[string]interface{}) (interface{}, error) {
+	m, err := modelByLabel(r.relType, true)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return &Edge{ElementID: r.id, StartID: r.startID, EndID: r.endID, Type: r.relType, Props: r.props}, nil
+	}
+
+	elem := reflect.New(m.modelType)
+	if err := m.mapToStruct(r.props, elem.Interface()); err != nil {
+		return nil, err
+	}
+	for field, id := range map[string]string{m.startField: r.startID, m.endField: r.endID} {
+		node, ok := nodes[id]
+		if !ok {
+			continue
+		}
+		fieldVal := elem.Elem().FieldByName(field)
+		nodeVal := reflect.ValueOf(node)
+		if nodeVal.Type().AssignableTo(fieldVal.Type()) {
+			fieldVal.Set(nodeVal)
+		} else if nodeVal.Kind() == reflect.Ptr && nodeVal.Elem().Type().AssignableTo(fieldVal.Type()) {
+			fieldVal.Set(nodeVal.Elem())
+		}
+	}
+	return elem.Interface(), nil
+}
@@ -0,0 +1,114 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildTraverse(t *testing.T) {
+	client := &Client{config: &Config{}}
+
+	m := newModel(client, &relTestUser{}).
+		Where("n.id = $id", map[string]interface{}{"id": "U001"}).
+		Traverse("FRIENDS", Both, 1, 3).
+		Traverse("OWNS", Outgoing)
+	var projects []*relTestProject
+	m.bindTarget(&projects)
+	expected := "MATCH (n:User) WHERE (n.id = $id) MATCH p = (n)-[:FRIENDS*1..3]-(t0)-[:OWNS]->(t1:Project) RETURN DISTINCT t1 "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	m = newModel(client, &relTestUser{}).Traverse("REPORTS_TO", Incoming, 2)
+	expected = "MATCH (n:User) MATCH p = (n)<-[:REPORTS_TO*2]-(t0) RETURN p "
+	if query := m.buildQueryReturning("p"); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	if err := newModel(client, &relTestUser{}).Traverse("FRIENDS", "sideways").FindPaths(&[]Path{}); err == nil {
+		t.Errorf("expected error for invalid direction")
+	}
+	if err := newModel(client, &relTestUser{}).Traverse("FRIENDS", Outgoing, 3, 1).Find(&[]relTestUser{}); err == nil {
+		t.Errorf("expected error for invalid depth")
+	}
+	if err := newModel(client, &relTestUser{}).FindPaths(&[]Path{}); err == nil {
+		t.Errorf("expected error when FindPaths is called without Traverse")
+	}
+}
+
+func TestToPath(t *testing.T) {
+	client := &Client{config: &Config{}}
+	newModel(client, &relTestUser{})
+	newModel(client, &relTestProject{})
+	newModel(client, &relTestOwnership{})
+
+	gp := &graphPath{
+		nodes: []*graphNode{
+			{id: "1", labels: []string{"User"}, props: map[string]interface{}{"id": "U001"}},
+			{id: "2", labels: []string{"Project"}, props: map[string]interface{}{"id": "P001"}},
+			{id: "3", labels: []string{"Tag"}, props: map[string]interface{}{"name": "graph"}},
+		},
+		relationships: []*graphRelationship{
+			{id: "10", startID: "1", endID: "2", relType: "OWNS", props: map[string]interface{}{"role": "Owner"}},
+			{id: "11", startID: "2", endID: "3", relType: "TAGGED"},
+		},
+	}
+	path, err := toPath(gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user, ok := path.Nodes[0].(*relTestUser); !ok || user.ID != "U001" {
+		t.Errorf("expected *relTestUser, got %#v", path.Nodes[0])
+	}
+	if tag, ok := path.Nodes[2].(*Node); !ok || tag.Props["name"] != "graph" {
+		t.Errorf("expected *Node for unregistered label, got %#v", path.Nodes[2])
+	}
+
+	owns, ok := path.Relationships[0].(*relTestOwnership)
+	if !ok {
+		t.Fatalf("expected *relTestOwnership, got %#v", path.Relationships[0])
+	}
+	if owns.Role != "Owner" || owns.StartNode != path.Nodes[0] || owns.EndNode != path.Nodes[1] {
+		t.Errorf("unexpected relationship: %+v", owns)
+	}
+	if edge, ok := path.Relationships[1].(*Edge); !ok || edge.Type != "TAGGED" || edge.EndID != "3" {
+		t.Errorf("expected *Edge for unregistered type, got %#v", path.Relationships[1])
+	}
+}
+
+type projectSummary struct {
+	ID string `neo4j:"name=id,label=Project"`
+}
+
+type dupTestA struct {
+	ID string `neo4j:"name=id,primary,label=Dup"`
+}
+
+type dupTestB struct {
+	ID string `neo4j:"name=id,primary,label=Dup"`
+}
+
+func TestModelByLabel(t *testing.T) {
+	client := &Client{config: &Config{}}
+	newModel(client, &relTestProject{})
+
+	// 映射查询结果的结构体不注册，不会影响按标签查找模型
+	var summary projectSummary
+	node := &graphNode{labels: []string{"Project"}, props: map[string]interface{}{"id": "P001"}}
+	if err := scanValue(client, reflect.ValueOf(&summary).Elem(), node, "p"); err != nil || summary.ID != "P001" {
+		t.Fatalf("unexpected summary: %+v, %v", summary, err)
+	}
+	if value, err := toNode(node); err != nil {
+		t.Fatal(err)
+	} else if _, ok := value.(*relTestProject); !ok {
+		t.Errorf("expected *relTestProject, got %#v", value)
+	}
+
+	newModel(client, &dupTestA{})
+	newModel(client, &dupTestB{})
+	if _, err := toNode(&graphNode{labels: []string{"Dup"}}); err == nil || !strings.Contains(err.Error(), "dupTestA") {
+		t.Errorf("expected an error for a label used by two models, got %v", err)
+	}
+}
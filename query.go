@@ -1,17 +1,54 @@
 package neo4jorm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
-
-	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"time"
 )
 
-// Where 添加查询条件
+// Where 添加查询条件，condition可以是同类型结构体（非零字段相等）、Cypher字符串，
+// 或 Eq、Gt、Or 等类型化条件，此时args中的其他条件一并以 AND 连接。
+// 字符串条件中可以直接使用结构体字段名和 ? 占位符，如 Where("SKU = ? AND Price > ?", sku, 10)，
+// 也可以使用 $name 命名参数，此时最后一个参数为 map[string]interface{}。
+// 多次调用 Where 的条件以 AND 连接，参数一并保留
 func (m *Model) Where(condition interface{}, args ...interface{}) *Model {
-	m.params = make(map[string]interface{})
+	m.conditions = append(m.conditions, m.buildConditions(condition, args)...)
+	return m
+}
+
+// Or 将已有条件与新条件以 OR 连接，参数与 Where 相同，如 Where(a).Where(b).Or(c) 生成 ((a AND b) OR (c))，
+// 之后添加的条件与整个 OR 表达式以 AND 连接
+func (m *Model) Or(condition interface{}, args ...interface{}) *Model {
+	conds := m.buildConditions(condition, args)
+	if len(conds) == 0 {
+		return m
+	}
+	if len(m.conditions) == 0 {
+		m.conditions = conds
+		return m
+	}
+	m.conditions = []string{fmt.Sprintf("((%s) OR (%s))",
+		strings.Join(m.conditions, " AND "), strings.Join(conds, " AND "))}
+	return m
+}
+
+// Not 添加取反的条件，参数与 Where 相同，多个条件先以 AND 连接再取反
+func (m *Model) Not(condition interface{}, args ...interface{}) *Model {
+	if conds := m.buildConditions(condition, args); len(conds) > 0 {
+		m.conditions = append(m.conditions, fmt.Sprintf("NOT (%s)", strings.Join(conds, " AND ")))
+	}
+	return m
+}
+
+// buildConditions 将 Where、Or、Not 的参数转换为条件表达式并保存参数，表达式之间为 AND 关系
+func (m *Model) buildConditions(condition interface{}, args []interface{}) []string {
+	if cond, ok := condition.(Condition); ok {
+		return m.typedConditions(cond, args)
+	}
+
 	// 类型反射处理
 	condVal := reflect.ValueOf(condition)
 	if condVal.Kind() == reflect.Ptr {
@@ -20,77 +57,292 @@ func (m *Model) Where(condition interface{}, args ...interface{}) *Model {
 
 	// 类型匹配检查
 	if condVal.IsValid() && condVal.Type() == m.modelType {
-		// 处理同类型结构体条件
+		// 处理同类型结构体条件，非零属性字段按相等比较
 		var conditions []string
-		params := make(map[string]interface{})
-
 		for i := 0; i < condVal.NumField(); i++ {
 			field := m.modelType.Field(i)
 			fieldVal := condVal.Field(i)
 
-			// 跳过零值字段
+			// 跳过零值字段和关系字段
 			if isZeroValue(fieldVal) {
 				continue
 			}
+			if !m.isPropertyField(field.Name) {
+				continue
+			}
 
 			// 获取映射后的属性名
 			propName := m.fieldMap[field.Name]
 
 			// 构造条件表达式
-			paramKey := fmt.Sprintf("%s_%d", propName, len(m.params))
+			value, err := m.propertyValue(propName, fieldVal.Interface())
+			if err != nil {
+				m.setErr(err)
+				return nil
+			}
+			paramKey := m.addParam(propName, value)
 			conditions = append(conditions, fmt.Sprintf("n.%s = $%s", propName, paramKey))
-			params[paramKey] = fieldVal.Interface()
 		}
 
 		if len(conditions) > 0 {
-			m.conditions = append(m.conditions, strings.Join(conditions, " AND "))
-			for k, v := range params {
-				m.params[k] = v
-			}
+			return []string{strings.Join(conditions, " AND ")}
 		}
-	} else {
-		// 处理字符串条件
-		switch c := condition.(type) {
-		case string:
-			m.conditions = append(m.conditions, c)
-			if len(args) > 0 {
-				if params, ok := args[0].(map[string]interface{}); ok {
-					for k, v := range params {
-						m.params[k] = v
-					}
-				}
-			}
+		return nil
+	}
+
+	// 处理字符串条件，加上括号避免其中的 OR 与其他条件的 AND 优先级混淆
+	switch c := condition.(type) {
+	case string:
+		expr, err := m.stringCondition(c, args)
+		if err != nil {
+			m.setErr(err)
+			return nil
+		}
+		return []string{"(" + expr + ")"}
+	case nil:
+	default:
+		m.setErr(fmt.Errorf("where: unsupported condition type %T", condition))
+	}
+	return nil
+}
+
+// typedConditions 构建类型化条件，args中只能是 Condition
+func (m *Model) typedConditions(cond Condition, args []interface{}) []string {
+	conds := []Condition{cond}
+	for _, arg := range args {
+		c, ok := arg.(Condition)
+		if !ok {
+			m.setErr(fmt.Errorf("where: expected Condition, got %T", arg))
+			return nil
 		}
+		conds = append(conds, c)
 	}
 
-	return m
+	exprs := make([]string, 0, len(conds))
+	for _, c := range conds {
+		expr, err := c.build(m)
+		if err != nil {
+			m.setErr(err)
+			return nil
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs
 }
 
-// OrderBy 添加排序条件
+// OrderBy 添加排序条件，每一项为字段名加可选的 ASC/DESC，如 OrderBy("Price DESC", "Name")，
+// 也可以使用 Desc、Asc 构造。字段名（或属性名）映射为 n.<属性名>，其他表达式（如聚合、
+// 关系实体的起止节点属性 s.name）中的字段名同样会被替换，遍历查询时字段属于终点节点
 func (m *Model) OrderBy(fields ...string) *Model {
-	m.orderBy = append(m.orderBy, fmt.Sprintf(" '%s' ", strings.Join(fields, "','")))
+	m.orderBy = append(m.orderBy, fields...)
 	return m
 }
 
+// Desc 降序排序项
+func Desc(field string) string {
+	return field + " DESC"
+}
+
+// Asc 升序排序项
+func Asc(field string) string {
+	return field + " ASC"
+}
+
+// orderClause 解析排序项生成 ORDER BY 的内容，解析失败时记录错误
+func (m *Model) orderClause() string {
+	model, alias := m, "n"
+	if len(m.hops) > 0 && m.target != nil {
+		model, alias = m.target, m.traverseVar()
+	}
+
+	items := make([]string, 0, len(m.orderBy))
+	for _, item := range m.orderBy {
+		expr, direction := splitDirection(item)
+		if expr == "" {
+			continue
+		}
+		if prop, err := model.propertyName(expr); err == nil {
+			if column, ok := m.selectedAlias(prop); ok {
+				expr = column
+			} else {
+				expr = alias + "." + prop
+			}
+		} else {
+			rewritten, err := model.rewriteFields(expr, alias)
+			if err != nil {
+				m.setErr(err)
+				continue
+			}
+			if strings.Contains(rewritten, placeholder) {
+				m.setErr(fmt.Errorf("order by %q: placeholders are not supported", item))
+				continue
+			}
+			expr = rewritten
+		}
+		if direction != "" {
+			expr += " " + direction
+		}
+		items = append(items, expr)
+	}
+	return strings.Join(items, ", ")
+}
+
+// splitDirection 拆分排序项末尾的排序方向
+func splitDirection(item string) (string, string) {
+	item = strings.TrimSpace(item)
+	i := strings.LastIndexAny(item, " \t")
+	if i < 0 {
+		return item, ""
+	}
+	switch direction := strings.ToUpper(item[i+1:]); direction {
+	case "ASC", "ASCENDING", "DESC", "DESCENDING":
+		return strings.TrimSpace(item[:i]), direction
+	}
+	return item, ""
+}
+
 // Limit 设置结果数量限制
 func (m *Model) Limit(limit int) *Model {
 	m.limit = limit
 	return m
 }
 
+// Offset 设置跳过的结果数量
+func (m *Model) Offset(offset int) *Model {
+	m.skip = offset
+	return m
+}
+
+// Preload 预加载关系字段，关联节点通过模式推导式在同一次查询中返回并填充到对应字段
+func (m *Model) Preload(fields ...string) *Model {
+	m.preloads = append(m.preloads, fields...)
+	return m
+}
+
+// checkPreloads 校验预加载的字段都是已声明的关系字段，且关联模型已声明标签
+func (m *Model) checkPreloads() error {
+	for _, field := range m.preloads {
+		if _, ok := m.relations[field]; !ok {
+			return fmt.Errorf("%s: %s is not a relationship field of %s", ErrInvalidModel, field, m.modelType)
+		}
+		if target := m.fieldModel(field); target.table == "" {
+			return fmt.Errorf("%s: related model %s has no label", ErrInvalidModel, target.modelType)
+		}
+	}
+	return nil
+}
+
+// fieldModel 获取关系字段或关系实体起止节点字段对应的模型
+func (m *Model) fieldModel(field string) *Model {
+	sf, _ := m.modelType.FieldByName(field)
+	t := sf.Type
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return newModel(m.client, reflect.New(t).Elem().Interface())
+}
+
+// buildPreloads 为每个预加载字段生成模式推导式，如 [(n)-[:FRIENDS]-(p0:User) | p0] AS Friends
+func (m *Model) buildPreloads() string {
+	var sb strings.Builder
+	for i, field := range m.preloads {
+		config := m.relations[field]
+		target := m.fieldModel(field)
+		alias := fmt.Sprintf("p%d", i)
+		sb.WriteString(fmt.Sprintf(", [(n)%s(%s:%s) | %s] AS %s",
+			relPattern("", config.Type, config.Direction), alias, target.table, alias, field))
+	}
+	return sb.String()
+}
+
+// assignPreloads 将预加载的关联节点映射到结构体的关系字段，values与m.preloads一一对应
+func (m *Model) assignPreloads(values []interface{}, result reflect.Value) error {
+	for i, field := range m.preloads {
+		nodes, _ := values[i].([]interface{})
+		target := m.fieldModel(field)
+		fieldVal := result.FieldByName(field)
+
+		elems := make([]reflect.Value, 0, len(nodes))
+		for _, v := range nodes {
+			node, ok := v.(*graphNode)
+			if !ok {
+				return fmt.Errorf("preload %s did not return nodes", field)
+			}
+			elem := reflect.New(target.modelType)
+			if err := target.mapToStruct(node.props, elem.Interface()); err != nil {
+				return err
+			}
+			elems = append(elems, elem)
+		}
+
+		switch fieldVal.Kind() {
+		case reflect.Slice:
+			slice := reflect.MakeSlice(fieldVal.Type(), 0, len(elems))
+			for _, elem := range elems {
+				if fieldVal.Type().Elem().Kind() != reflect.Ptr {
+					elem = elem.Elem()
+				}
+				slice = reflect.Append(slice, elem)
+			}
+			fieldVal.Set(slice)
+		case reflect.Ptr:
+			if len(elems) > 0 {
+				fieldVal.Set(elems[0])
+			}
+		case reflect.Struct:
+			if len(elems) > 0 {
+				fieldVal.Set(elems[0].Elem())
+			}
+		}
+	}
+	return nil
+}
+
+// matchPattern 生成MATCH模式，节点为 (n:Label)，关系实体为 (s:Start)-[n:TYPE]->(e:End)，
+// 两者都以n作为查询条件和排序的变量
+func (m *Model) matchPattern() string {
+	if m.isRelationship() {
+		start, end := m.fieldModel(m.startField), m.fieldModel(m.endField)
+		return fmt.Sprintf("(s:%s)-[n:%s]->(e:%s)", start.table, m.table, end.table)
+	}
+	return fmt.Sprintf("(n:%s)", m.table)
+}
+
+// returnClause 生成RETURN的列，遍历查询返回去重后的目标节点；指定了 Select 时返回选择的列，
+// 否则返回整个节点或关系。关系实体额外返回起止节点，节点额外返回预加载的关联节点
+func (m *Model) returnClause() string {
+	if len(m.hops) > 0 {
+		return "DISTINCT " + m.traverseVar()
+	}
+	// 指定了 Select 时只返回选择的属性
+	columns := "n"
+	if len(m.selects) > 0 {
+		columns = strings.Join(m.projection(), ", ")
+	}
+	if m.isRelationship() {
+		return columns + ", s, e"
+	}
+	return columns + m.buildPreloads()
+}
+
 // buildQuery 构建Cypher查询语句
 func (m *Model) buildQuery() string {
-	var query strings.Builder
-	query.WriteString(fmt.Sprintf("MATCH (n:%s)", m.table))
+	return m.buildQueryReturning(m.returnClause())
+}
 
-	// 处理WHERE条件
-	if len(m.conditions) > 0 {
-		query.WriteString(" WHERE " + strings.Join(m.conditions, " AND "))
-	}
-	query.WriteString(" RETURN n ")
+// buildQueryReturning 使用指定的RETURN子句构建查询，条件、遍历、排序、偏移和数量限制与 buildQuery 相同
+func (m *Model) buildQueryReturning(returnClause string) string {
+	var query strings.Builder
+	query.WriteString(m.buildMatch())
+	query.WriteString(" RETURN " + returnClause + " ")
 	// 处理ORDER BY
-	if len(m.orderBy) > 0 {
-		query.WriteString(" ORDER BY " + strings.Join(m.orderBy, ", "))
+	if order := m.orderClause(); order != "" {
+		query.WriteString(" ORDER BY " + order)
+	}
+
+	// 处理SKIP
+	if m.skip > 0 {
+		query.WriteString(fmt.Sprintf(" SKIP %d", m.skip))
 	}
 
 	// 处理LIMIT
@@ -101,29 +353,85 @@ func (m *Model) buildQuery() string {
 	return query.String()
 }
 
+// buildMatch 构建查询的MATCH、WHERE和遍历部分，供查询结果和统计数量共用
+func (m *Model) buildMatch() string {
+	var query strings.Builder
+	query.WriteString("MATCH " + m.matchPattern())
+
+	// 处理WHERE条件
+	if len(m.conditions) > 0 {
+		query.WriteString(" WHERE " + strings.Join(m.conditions, " AND "))
+	}
+	// 处理关系遍历
+	if len(m.hops) > 0 {
+		query.WriteString(" " + m.buildTraverse())
+	}
+	return query.String()
+}
+
 // FindOne 查询单个结果
 func (m *Model) FindOne(result interface{}) error {
+	m.bindTarget(result)
+	m.applyKeyset()
 	return m.executeQuery(m.Limit(1).buildQuery(), result, true)
 }
 
-// Find 查询多个结果
+// Find 查询多个结果，使用 After 游标分页时之后可以通过 Cursor 获取下一页的游标
 func (m *Model) Find(results interface{}) error {
-	return m.executeQuery(m.buildQuery(), results, false)
+	m.bindTarget(results)
+	// applyKeyset 会改写排序，必须在读取 m.orderBy 之前调用
+	keys := m.applyKeyset()
+	order, limit := m.orderBy, m.limit
+	m.cursor = ""
+	if err := m.executeQuery(m.buildQuery(), results, false); err != nil {
+		return err
+	}
+
+	// 结果已满一页时用最后一行生成下一页游标
+	rows := reflect.ValueOf(results).Elem()
+	if keys == nil || limit <= 0 || rows.Len() < limit {
+		return nil
+	}
+	cursor, err := encodeCursor(keys, order, rows.Index(rows.Len()-1))
+	if err != nil {
+		return err
+	}
+	m.cursor = cursor
+	return nil
 }
 
-// executeQuery 执行查询并处理结果映射
-func (m *Model) executeQuery(query string, out interface{}, single bool) error {
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
+// FindOneCtx 使用指定上下文查询单个结果
+func (m *Model) FindOneCtx(ctx context.Context, result interface{}) error {
+	return m.withCtx(ctx, func() error { return m.FindOne(result) })
+}
 
-	if m.debug {
-		fmt.Printf("Executing Query:\n%s\nWith params: %+v\n", query, m.params)
+// FindCtx 使用指定上下文查询多个结果
+func (m *Model) FindCtx(ctx context.Context, results interface{}) error {
+	return m.withCtx(ctx, func() error { return m.Find(results) })
+}
+
+// query 执行读查询并返回全部记录。绑定了用户事务时在事务内读取，保证能看到事务中未提交的写入，
+// 否则通过驱动的 executeQuery 路由到只读节点执行
+func (m *Model) query(query string, params map[string]interface{}) ([]*record, error) {
+	ctx := m.context()
+	if m.tx != nil {
+		result, err := m.tx.tx.run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return collectRecords(ctx, result)
 	}
+	return m.client.driver.executeQuery(ctx, m.client.config.Database, query, params, false, nil)
+}
 
-	result, err := session.Run(query, m.params)
-	if err != nil {
+// executeQuery 执行查询并处理结果映射
+func (m *Model) executeQuery(query string, out interface{}, single bool) error {
+	// 无论成功与否都清理查询条件，避免影响同一模型的下次查询
+	defer m.cleanQuery()
+	if m.err != nil {
+		return m.err
+	}
+	if err := m.checkPreloads(); err != nil {
 		return err
 	}
 
@@ -139,44 +447,112 @@ func (m *Model) executeQuery(query string, out interface{}, single bool) error {
 		return errors.New("results must be a pointer to a slice")
 	}
 
-	for result.Next() {
-		record := result.Record()
-		node, ok := record.GetByIndex(0).(neo4j.Node)
-		if !ok {
-			return errors.New("query did not return a node")
-		}
+	// 遍历查询返回目标节点，映射到目标模型；否则映射到当前模型
+	target := m
+	if m.target != nil {
+		target = m.target
+	}
+	elemType := sliceVal.Type()
+	if !single {
+		elemType = elemType.Elem()
+	}
+	ptrElem := elemType.Kind() == reflect.Ptr
+	if ptrElem {
+		elemType = elemType.Elem()
+	}
+	if elemType != target.modelType {
+		return fmt.Errorf("%s: result type %s does not match model %s", ErrInvalidModel, elemType, target.modelType)
+	}
 
+	if m.debug {
+		fmt.Printf("Executing Query:\n%s\nWith params: %+v\n", query, m.params)
+	}
+
+	records, err := m.query(query, m.params)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
 		// 创建新实例并映射属性
-		elem := reflect.New(m.modelType).Interface()
-		if err := m.mapToStruct(node.Props, elem); err != nil {
+		elem := reflect.New(target.modelType)
+		if err := target.scanRecord(record, elem.Interface()); err != nil {
 			return err
 		}
+		if !ptrElem {
+			elem = elem.Elem()
+		}
 
 		if single {
-			outVal.Elem().Set(reflect.ValueOf(elem).Elem())
+			outVal.Elem().Set(elem)
 			return nil // 找到即返回
 		} else {
-			sliceVal.Set(reflect.Append(sliceVal, reflect.ValueOf(elem).Elem()))
+			sliceVal.Set(reflect.Append(sliceVal, elem))
 		}
 	}
 
-	if err := result.Err(); err != nil {
-		return err
-	}
-
 	if single {
 		return errors.New("no records found")
 	}
-	// 查询玩后将参数清零，避免影响下次查询
-	m.cleanQuery()
 	return nil
 }
 
+// scanRecord 将 buildQuery 返回的一条记录映射到结构体：前面的列为节点或关系实体，
+// 指定了 Select 时为按列名对应属性的多列；其后依次为关系实体的起止节点或预加载的关联节点
+func (m *Model) scanRecord(rec *record, result interface{}) error {
+	extra := len(m.preloads)
+	if m.isRelationship() {
+		extra = 2
+	}
+	columns := len(rec.values) - extra
+
+	var props map[string]interface{}
+	if len(m.selects) > 0 {
+		props = make(map[string]interface{}, columns)
+		aliases := m.selectedProps()
+		for i := 0; i < columns; i++ {
+			key := rec.keys[i]
+			if prop, ok := aliases[key]; ok {
+				key = prop
+			}
+			props[key] = rec.values[i]
+		}
+	} else {
+		switch v := rec.values[0].(type) {
+		case *graphNode:
+			props = v.props
+		case *graphRelationship:
+			props = v.props
+		default:
+			return errors.New("query did not return a node")
+		}
+	}
+	if err := m.mapToStruct(props, result); err != nil {
+		return err
+	}
+
+	resultVal := reflect.ValueOf(result).Elem()
+	if m.isRelationship() {
+		return m.assignEndpoints(rec.values[columns:], resultVal)
+	}
+	return m.assignPreloads(rec.values[columns:], resultVal)
+}
+
 func (m *Model) cleanQuery() {
 	m.conditions = nil
 	m.params = nil
+	m.paramSeq = 0
 	m.orderBy = nil
 	m.limit = 0
+	m.skip = 0
+	m.keyset = false
+	m.after = ""
+	m.preloads = nil
+	m.selects = nil
+	m.groupBy = nil
+	m.hops = nil
+	m.target = nil
+	m.err = m.invalid
 }
 
 // mapToStruct 将节点属性映射到结构体
@@ -186,6 +562,9 @@ func (m *Model) mapToStruct(properties map[string]interface{}, result interface{
 	for i := 0; i < resultVal.NumField(); i++ {
 		field := resultVal.Type().Field(i)
 		fieldVal := resultVal.Field(i)
+		if !m.isPropertyField(field.Name) {
+			continue
+		}
 
 		// 获取映射属性名
 		propName := m.fieldMap[field.Name]
@@ -199,90 +578,110 @@ func (m *Model) mapToStruct(properties map[string]interface{}, result interface{
 			continue // 属性不存在时跳过
 		}
 
-		// 处理指针类型的特殊逻辑
-		if fieldVal.Kind() == reflect.Ptr {
-			if value == nil {
-				fieldVal.Set(reflect.Zero(fieldVal.Type())) // 设置为nil指针
-				continue
-			}
+		if err := assignValue(fieldVal, value, field.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			// 创建新的指针并赋值
-			elemType := fieldVal.Type().Elem() // 获取指针指向的类型
+// assignValue 将数据库返回的值转换为字段类型后赋值，name用于错误信息
+func assignValue(fieldVal reflect.Value, value interface{}, name string) error {
+	// 处理指针类型的特殊逻辑
+	if fieldVal.Kind() == reflect.Ptr {
+		if value == nil {
+			fieldVal.Set(reflect.Zero(fieldVal.Type())) // 设置为nil指针
+			return nil
+		}
 
-			// 根据指针指向的类型进行转换
-			switch elemType.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if v, ok := convertToInt(reflect.ValueOf(value)); ok {
-					ptr := reflect.New(elemType)
-					ptr.Elem().SetInt(v)
-					fieldVal.Set(ptr)
-				}
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				if v, ok := convertToUint(reflect.ValueOf(value)); ok {
-					ptr := reflect.New(elemType)
-					ptr.Elem().SetUint(v)
-					fieldVal.Set(ptr)
-				}
-			case reflect.Float32, reflect.Float64:
-				if v, ok := convertToFloat(reflect.ValueOf(value)); ok {
-					ptr := reflect.New(elemType)
-					ptr.Elem().SetFloat(v)
-					fieldVal.Set(ptr)
-				}
-			case reflect.String:
-				if s, ok := value.(string); ok {
-					ptr := reflect.New(elemType)
-					ptr.Elem().SetString(s)
-					fieldVal.Set(ptr)
-				}
-			default:
-				return fmt.Errorf("不支持的指针类型: %s", elemType.Kind())
+		// 创建新的指针并赋值
+		elemType := fieldVal.Type().Elem() // 获取指针指向的类型
+
+		// 根据指针指向的类型进行转换
+		switch elemType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v, ok := convertToInt(reflect.ValueOf(value)); ok {
+				ptr := reflect.New(elemType)
+				ptr.Elem().SetInt(v)
+				fieldVal.Set(ptr)
 			}
-			continue
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v, ok := convertToUint(reflect.ValueOf(value)); ok {
+				ptr := reflect.New(elemType)
+				ptr.Elem().SetUint(v)
+				fieldVal.Set(ptr)
+			}
+		case reflect.Float32, reflect.Float64:
+			if v, ok := convertToFloat(reflect.ValueOf(value)); ok {
+				ptr := reflect.New(elemType)
+				ptr.Elem().SetFloat(v)
+				fieldVal.Set(ptr)
+			}
+		case reflect.String:
+			if s, ok := value.(string); ok {
+				ptr := reflect.New(elemType)
+				ptr.Elem().SetString(s)
+				fieldVal.Set(ptr)
+			}
+		default:
+			// time.Time 等其他类型按非指针字段的规则转换后取地址
+			ptr := reflect.New(elemType)
+			if err := assignValue(ptr.Elem(), value, name); err != nil {
+				return err
+			}
+			fieldVal.Set(ptr)
 		}
+		return nil
+	}
 
-		// 处理空值
-		if value == nil {
-			if fieldVal.Kind() == reflect.Ptr {
-				fieldVal.Set(reflect.Zero(fieldVal.Type())) // 设置指针为nil
-			} else {
-				// 根据字段类型设置零值
-				zeroVal := reflect.Zero(fieldVal.Type())
-				if fieldVal.CanSet() {
-					fieldVal.Set(zeroVal)
-				}
-			}
-			continue
+	// 处理空值
+	if value == nil {
+		// 根据字段类型设置零值
+		if fieldVal.CanSet() {
+			fieldVal.Set(reflect.Zero(fieldVal.Type()))
 		}
+		return nil
+	}
 
-		// 类型转换处理
-		val := reflect.ValueOf(value)
-		if val.Type().AssignableTo(fieldVal.Type()) {
-			fieldVal.Set(val)
-		} else if val.Type().ConvertibleTo(fieldVal.Type()) {
-			convertedVal := val.Convert(fieldVal.Type())
-			fieldVal.Set(convertedVal)
-		} else {
-			// 处理常见类型不匹配情况
-			switch fieldVal.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if v, ok := convertToInt(val); ok {
-					fieldVal.SetInt(v)
-				}
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				if v, ok := convertToUint(val); ok {
-					fieldVal.SetUint(v)
-				}
-			case reflect.Float32, reflect.Float64:
-				if v, ok := convertToFloat(val); ok {
-					fieldVal.SetFloat(v)
-				}
-			case reflect.String:
-				fieldVal.SetString(fmt.Sprintf("%v", value))
-			default:
+	// 类型转换处理
+	val := reflect.ValueOf(value)
+	if val.Type().AssignableTo(fieldVal.Type()) {
+		fieldVal.Set(val)
+	} else if val.Type().ConvertibleTo(fieldVal.Type()) {
+		convertedVal := val.Convert(fieldVal.Type())
+		fieldVal.Set(convertedVal)
+	} else {
+		// 处理常见类型不匹配情况
+		switch fieldVal.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v, ok := convertToInt(val); ok {
+				fieldVal.SetInt(v)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v, ok := convertToUint(val); ok {
+				fieldVal.SetUint(v)
+			}
+		case reflect.Float32, reflect.Float64:
+			if v, ok := convertToFloat(val); ok {
+				fieldVal.SetFloat(v)
+			}
+		case reflect.String:
+			fieldVal.SetString(fmt.Sprintf("%v", value))
+		case reflect.Struct:
+			// 以字符串保存的时间按 RFC 3339 解析
+			s, ok := value.(string)
+			if fieldVal.Type() != timeType || !ok {
 				return fmt.Errorf("字段 %s 类型不匹配 (数据库类型: %T, 结构体类型: %s)",
-					field.Name, value, fieldVal.Type().String())
+					name, value, fieldVal.Type().String())
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return fmt.Errorf("字段 %s 时间格式错误: %w", name, err)
 			}
+			fieldVal.Set(reflect.ValueOf(t))
+		default:
+			return fmt.Errorf("字段 %s 类型不匹配 (数据库类型: %T, 结构体类型: %s)",
+				name, value, fieldVal.Type().String())
 		}
 	}
 	return nil
@@ -293,6 +692,5 @@ func (m *Model) FindByPrimaryKey(value interface{}, result interface{}) error {
 	if m.primaryKey == "" {
 		return errors.New("primary key not defined")
 	}
-	propName := m.fieldMap[m.primaryKey]
-	return m.Where(fmt.Sprintf("n.%s = $pk", propName), map[string]interface{}{"pk": value}).FindOne(result)
+	return m.Where(Eq(m.primaryKey, value)).FindOne(result)
 }
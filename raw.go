@@ -0,0 +1,85 @@
+package neo4jorm
+
+import (
+	"context"
+	"fmt"
+)
+
+// RawQuery 原生 Cypher 查询，用于查询构建器无法表达的语句
+type RawQuery struct {
+	client *Client
+	tx     *Transaction
+	ctx    context.Context
+	query  string
+	params map[string]interface{}
+	write  bool // Scan 路由到写节点
+}
+
+// Raw 创建原生 Cypher 查询，通过 Scan 读取结果或 Exec 执行写语句
+func (c *Client) Raw(query string, params map[string]interface{}) *RawQuery {
+	return &RawQuery{client: c, query: query, params: params}
+}
+
+// Raw 创建在当前事务中执行的原生 Cypher 查询
+func (t *Transaction) Raw(query string, params map[string]interface{}) *RawQuery {
+	return &RawQuery{client: t.client, tx: t, query: query, params: params}
+}
+
+// WithContext 使用指定上下文执行查询
+func (r *RawQuery) WithContext(ctx context.Context) *RawQuery {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+// Write 以写查询执行 Scan，用于 CREATE ... RETURN 等需要读取写入结果的语句，
+// 集群中路由到主节点
+func (r *RawQuery) Write() *RawQuery {
+	c := *r
+	c.write = true
+	return &c
+}
+
+// Scan 执行查询并按列名映射到dest，默认以读查询执行，写语句需先调用 Write。dest可以是结构体、map[string]interface{}、Path 或标量的切片指针，
+// 也可以是单个值的指针。结构体按列名匹配字段；只返回一列节点或关系时映射整个节点；
+// 节点、关系和路径按标签或关系类型映射为已注册的模型
+func (r *RawQuery) Scan(dest interface{}) error {
+	records, err := r.run(r.write)
+	if err != nil {
+		return err
+	}
+	return scanRecords(r.client, records, dest)
+}
+
+// Exec 执行写语句并丢弃结果
+func (r *RawQuery) Exec() error {
+	_, err := r.run(true)
+	return err
+}
+
+// run 执行查询，绑定了事务时在事务中执行，否则通过驱动的 executeQuery 执行，write为false时路由到只读节点
+func (r *RawQuery) run(write bool) ([]*record, error) {
+	ctx := r.context()
+	if r.client.debug {
+		fmt.Printf("Executing Raw:\n%s\nWith params: %+v\n", r.query, r.params)
+	}
+	if r.tx != nil {
+		result, err := r.tx.tx.run(ctx, r.query, r.params)
+		if err != nil {
+			return nil, err
+		}
+		return collectRecords(ctx, result)
+	}
+	return r.client.driver.executeQuery(ctx, r.client.config.Database, r.query, r.params, write, nil)
+}
+
+// context 获取查询使用的上下文，依次取查询上下文、事务上下文和默认上下文
+func (r *RawQuery) context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	if r.tx != nil && r.tx.ctx != nil {
+		return r.tx.ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,91 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRawScan(t *testing.T) {
+	client := &Client{config: &Config{}}
+	newModel(client, &relTestUser{})
+	newModel(client, &relTestProject{})
+
+	user := &graphNode{id: "1", labels: []string{"User"}, props: map[string]interface{}{"id": "U001", "name": "Alice"}}
+	project := &graphNode{id: "2", labels: []string{"Project"}, props: map[string]interface{}{"id": "P001"}}
+	path := &graphPath{
+		nodes:         []*graphNode{user, project},
+		relationships: []*graphRelationship{{id: "3", startID: "1", endID: "2", relType: "LIKES"}},
+	}
+	var records []*record
+	client.driver = &fakeDriver{results: func(query string) []*record { return records }}
+
+	// 只有一列节点时映射整个节点
+	records = []*record{{keys: []string{"u"}, values: []interface{}{user}}}
+	var users []relTestUser
+	if err := client.Raw("MATCH (u:User) RETURN u", nil).Scan(&users); err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Name != "Alice" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+
+	// 多列按列名映射，节点列映射到结构体字段
+	type ownerRow struct {
+		Owner    *relTestUser
+		Projects []relTestProject
+		Total    int
+		Path     Path
+	}
+	records = []*record{{
+		keys:   []string{"owner", "projects", "total", "path"},
+		values: []interface{}{user, []interface{}{project}, int64(1), path},
+	}}
+	var row ownerRow
+	if err := client.Raw("MATCH ... RETURN owner, projects, total, path", map[string]interface{}{"id": "U001"}).Scan(&row); err != nil {
+		t.Fatal(err)
+	}
+	if row.Owner == nil || row.Owner.ID != "U001" || len(row.Projects) != 1 || row.Projects[0].ID != "P001" || row.Total != 1 {
+		t.Errorf("unexpected row: %+v", row)
+	}
+	if len(row.Path.Nodes) != 2 || row.Path.Relationships[0].(*Edge).Type != "LIKES" {
+		t.Errorf("unexpected path: %+v", row.Path)
+	}
+
+	// map 中的节点按标签映射为已注册模型
+	var maps []map[string]interface{}
+	if err := client.Raw("MATCH ... RETURN owner, total", nil).Scan(&maps); err != nil {
+		t.Fatal(err)
+	}
+	if owner, ok := maps[0]["owner"].(*relTestUser); !ok || owner.Name != "Alice" || maps[0]["total"] != int64(1) {
+		t.Errorf("unexpected maps: %+v", maps)
+	}
+
+	// 标量切片取第一列
+	records = []*record{{keys: []string{"name"}, values: []interface{}{"Alice"}}, {keys: []string{"name"}, values: []interface{}{"Bob"}}}
+	var names []string
+	if err := client.Raw("MATCH (u:User) RETURN u.name AS name", nil).Scan(&names); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"Alice", "Bob"}) {
+		t.Errorf("unexpected names: %v", names)
+	}
+	if client.driver.(*fakeDriver).write {
+		t.Errorf("expected Scan to run as a read query")
+	}
+
+	// Write 以写查询执行并读取写入的结果
+	records = []*record{{keys: []string{"u"}, values: []interface{}{user}}}
+	var created relTestUser
+	if err := client.Raw("CREATE (u:User {id: $id}) RETURN u", map[string]interface{}{"id": "U001"}).Write().Scan(&created); err != nil {
+		t.Fatal(err)
+	}
+	if !client.driver.(*fakeDriver).write || created.ID != "U001" {
+		t.Errorf("expected a write-routed scan, got %+v", created)
+	}
+
+	var count int64
+	records = nil
+	if err := client.Raw("RETURN 1", nil).Scan(&count); err == nil {
+		t.Errorf("expected error when scanning a single value without records")
+	}
+}
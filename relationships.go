@@ -1,11 +1,10 @@
 package neo4jorm
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
-
-	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
 )
 
 // RelationshipConfig 存储关系配置
@@ -59,127 +58,145 @@ func extractRelationshipProps(obj interface{}) map[string]interface{} {
 type Relation struct {
 	Start interface{} // 起始节点结构体
 	End   interface{} // 目标节点结构体
+	Type  string      // 关系类型，为空时使用批量方法传入的类型
 }
 
-// 批量创建无属性关系方法
+// 批量创建无属性关系方法，关系方向为outgoing，使用MERGE避免重复创建
 func (m *Model) CreateRelations(relations []Relation, relType string) error {
+	return m.CreateRelationsWithConfig(relations, RelationshipConfig{
+		Type:      relType,
+		Direction: Outgoing,
+		Merge:     true,
+	})
+}
+
+// CreateRelationsWithConfig 按关系配置批量创建无属性关系，起止节点不存在时按主键创建。
+// Direction为incoming时关系从End指向Start；为both时不区分方向，MERGE会匹配任一方向的已有关系，
+// 不会重复写入双向关系
+func (m *Model) CreateRelationsWithConfig(relations []Relation, config RelationshipConfig) error {
+	if err := m.takeErr(); err != nil {
+		return err
+	}
 	// 空关系列表直接返回，避免无效操作
 	if len(relations) == 0 {
 		return nil
 	}
-
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
-
-	// 使用UNWIND优化批量操作
-	var query strings.Builder
-	query.WriteString("UNWIND $rels AS rel ")
-	query.WriteString("MERGE (a:%s { %s: rel.startVal }) ")
-	query.WriteString("MERGE (b:%s { %s: rel.endVal }) ")
-	query.WriteString("MERGE (a)-[r1:%s]->(b) ")
-	query.WriteString("MERGE (a)-[r2:%s]->(b) ")
-	var (
-		startPK string
-		endPK   string
-		start   *Model
-		end     *Model
-	)
-
-	// 提前校验并获取元数据
-	if len(relations) > 0 {
-		// 获取第一个关系的元数据（假设所有关系类型相同）
-		firstRel := relations[0]
-		start = newModel(m.client, firstRel.Start)
-		end = newModel(m.client, firstRel.End)
-		startPK = start.fieldMap[start.primaryKey]
-		endPK = end.fieldMap[end.primaryKey]
-	}
-
-	// 构建最终查询
-	finalQuery := fmt.Sprintf(query.String(),
-		start.table, startPK,
-		end.table, endPK,
-		relType,
-		relType,
-	)
-
-	// 准备批量参数
-	relsParams := make([]map[string]interface{}, 0, len(relations))
-	for _, rel := range relations {
-
-		relsParams = append(relsParams, map[string]interface{}{
-			"startVal": getStructKeyValue(rel.Start, start.primaryKey),
-			"endVal":   getStructKeyValue(rel.End, end.primaryKey),
-		})
+	if !validDirection(config.Direction) {
+		return fmt.Errorf("%s: invalid direction %q", ErrInvalidModel, config.Direction)
 	}
 
-	params := map[string]interface{}{
-		"rels": relsParams,
+	// 按起止节点类型和关系类型分组，每组一条UNWIND语句
+	batches, err := groupRelations(m, relations, config)
+	if err != nil {
+		return err
 	}
-
-	if m.debug {
-		fmt.Printf("Executing CreateRelations:\n%s\nWith params: %+v\n", finalQuery, params)
+	for _, batch := range batches {
+		batch.mergeNodes = true
 	}
 
 	// 执行批量操作
-	_, err := session.Run(finalQuery, params)
-	return err
+	return m.execRelationBatches(batches, buildRelationQuery, "CreateRelations")
 }
 
-// DeleteRelation 删除关系（使用主键判断）
+// DeleteRelation 删除关系（使用主键判断），关系方向为outgoing
 func (m *Model) DeleteRelations(relations []Relation, relType string) error {
+	return m.DeleteRelationsWithConfig(relations, RelationshipConfig{
+		Type:      relType,
+		Direction: Outgoing,
+	})
+}
+
+// DeleteRelationsWithConfig 按关系配置批量删除关系，Direction为both时删除两个方向上的关系
+func (m *Model) DeleteRelationsWithConfig(relations []Relation, config RelationshipConfig) error {
+	if err := m.takeErr(); err != nil {
+		return err
+	}
 	// 空关系列表直接返回，避免无效操作
 	if len(relations) == 0 {
 		return nil
 	}
+	if !validDirection(config.Direction) {
+		return fmt.Errorf("%s: invalid direction %q", ErrInvalidModel, config.Direction)
+	}
 
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
-
-	var query strings.Builder
-	// 使用UNWIND批量处理，MATCH定位关系后删除
-	query.WriteString("UNWIND $rels AS rel ")
-	query.WriteString("MATCH (a:%s {%s: rel.startVal})-[r:%s]->(b:%s {%s: rel.endVal}) ")
-	query.WriteString("DELETE r")
-
-	// 获取元数据（复用原有逻辑）
-	firstRel := relations[0]
-	start := newModel(m.client, firstRel.Start)
-	end := newModel(m.client, firstRel.End)
-	startPK := start.fieldMap[start.primaryKey]
-	endPK := end.fieldMap[end.primaryKey]
-
-	// 构建最终查询
-	finalQuery := fmt.Sprintf(query.String(),
-		start.table, startPK,
-		relType,
-		end.table, endPK,
-	)
-
-	// 准备批量参数（与CreateRelations保持相同结构）
-	relsParams := make([]map[string]interface{}, 0, len(relations))
-	for _, rel := range relations {
-		relsParams = append(relsParams, map[string]interface{}{
-			"startVal": getStructKeyValue(rel.Start, start.primaryKey),
-			"endVal":   getStructKeyValue(rel.End, end.primaryKey),
-		})
+	batches, err := groupRelations(m, relations, config)
+	if err != nil {
+		return err
 	}
 
-	params := map[string]interface{}{
-		"rels": relsParams,
+	// 执行删除操作
+	return m.execRelationBatches(batches, buildDeleteRelationQuery, "DeleteRelations")
+}
+
+// groupRelations 按(起始节点类型, 目标节点类型, 关系类型)对relations分组，保持首次出现的顺序。
+// Relation.Type为空时使用config.Type
+func groupRelations(m *Model, relations []Relation, config RelationshipConfig) ([]*relationBatch, error) {
+	type groupKey struct {
+		start, end reflect.Type
+		relType    string
 	}
 
-	if m.debug {
-		fmt.Printf("Executing Delete:\n%s\nWith params: %+v\n", finalQuery, params)
+	var batches []*relationBatch
+	index := make(map[groupKey]*relationBatch)
+	for i, rel := range relations {
+		if isNil(rel.Start) || isNil(rel.End) {
+			return nil, fmt.Errorf("%s: relation %d has no start or end node", ErrInvalidModel, i)
+		}
+
+		relConfig := config
+		if rel.Type != "" {
+			relConfig.Type = rel.Type
+		}
+		if relConfig.Type == "" {
+			return nil, fmt.Errorf("%s: relation %d has no type", ErrInvalidModel, i)
+		}
+
+		key := groupKey{start: getType(rel.Start), end: getType(rel.End), relType: relConfig.Type}
+		batch, ok := index[key]
+		if !ok {
+			batch = &relationBatch{
+				start:  newModel(m.client, rel.Start),
+				end:    newModel(m.client, rel.End),
+				config: relConfig,
+			}
+			for _, endpoint := range []*Model{batch.start, batch.end} {
+				if endpoint.table == "" || endpoint.primaryKey == "" {
+					return nil, fmt.Errorf("%s: %s needs a label and a primary key", ErrInvalidModel, endpoint.modelType)
+				}
+			}
+			index[key] = batch
+			batches = append(batches, batch)
+		}
+
+		batch.rels = append(batch.rels, map[string]interface{}{
+			"startVal": getStructKeyValue(rel.Start, batch.start.primaryKey),
+			"endVal":   getStructKeyValue(rel.End, batch.end.primaryKey),
+		})
 	}
+	return batches, nil
+}
 
-	// 执行删除操作
-	_, err := session.Run(finalQuery, params)
-	return err
+// execRelationBatches 在同一事务中逐组执行关系语句，任一组失败时整体回滚，并通过 RelationGroupError 返回失败的分组
+func (m *Model) execRelationBatches(batches []*relationBatch, build func(*relationBatch) (string, map[string]interface{}), action string) error {
+	ctx := m.context()
+	return m.runWrite(func(tx graphTx) error {
+		for _, batch := range batches {
+			query, params := build(batch)
+			if m.debug {
+				fmt.Printf("Executing %s:\n%s\nWith params: %+v\n", action, query, params)
+			}
+			if err := runAndConsume(ctx, tx, query, params); err != nil {
+				return &RelationGroupError{
+					Start: batch.start.table,
+					End:   batch.end.table,
+					Type:  batch.config.Type,
+					Count: len(batch.rels),
+					Err:   err,
+				}
+			}
+		}
+		return nil
+	}, nil)
 }
 
 // 获取主键值的辅助函数
@@ -191,3 +208,314 @@ func getStructKeyValue(model interface{}, fieldKey string) (Value interface{}) {
 
 	return val.FieldByName(fieldKey).Interface()
 }
+
+// relPattern 按方向生成关系模式，如 -[r:T]->、<-[r:T]-、-[r:T]-
+func relPattern(variable, relType, direction string) string {
+	switch direction {
+	case Incoming:
+		return fmt.Sprintf("<-[%s:%s]-", variable, relType)
+	case Both:
+		return fmt.Sprintf("-[%s:%s]-", variable, relType)
+	default:
+		return fmt.Sprintf("-[%s:%s]->", variable, relType)
+	}
+}
+
+// validDirection 校验关系方向，空字符串视为 outgoing
+func validDirection(direction string) bool {
+	switch direction {
+	case "", Outgoing, Incoming, Both:
+		return true
+	}
+	return false
+}
+
+// relationBatch 起止模型、关系类型和方向都相同的一批关系，可用一条 UNWIND 语句写入
+type relationBatch struct {
+	start      *Model
+	end        *Model
+	config     RelationshipConfig
+	rels       []map[string]interface{}
+	mergeNodes bool // 起止节点不存在时按主键创建，否则要求节点已存在
+}
+
+// buildRelationQuery 构建批量写入关系的语句，起止节点需已存在。
+// merge为true时使用MERGE，both方向的MERGE不区分方向匹配已有关系，
+// 因为CREATE必须指定方向，both方向的CREATE按outgoing写入
+func buildRelationQuery(b *relationBatch) (string, map[string]interface{}) {
+	nodeOp := "MATCH"
+	if b.mergeNodes {
+		nodeOp = "MERGE"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("UNWIND $rels AS rel ")
+	sb.WriteString(fmt.Sprintf("%s (a:%s { %s: rel.startVal }) ", nodeOp, b.start.table, b.start.fieldMap[b.start.primaryKey]))
+	sb.WriteString(fmt.Sprintf("%s (b:%s { %s: rel.endVal }) ", nodeOp, b.end.table, b.end.fieldMap[b.end.primaryKey]))
+	if b.config.Merge {
+		sb.WriteString("MERGE (a)" + relPattern("r", b.config.Type, b.config.Direction) + "(b)")
+	} else {
+		direction := b.config.Direction
+		if direction == Both {
+			direction = Outgoing
+		}
+		sb.WriteString("CREATE (a)" + relPattern("r", b.config.Type, direction) + "(b)")
+	}
+	return sb.String(), map[string]interface{}{"rels": b.rels}
+}
+
+// buildDeleteRelationQuery 构建批量删除关系的语句，both方向不区分方向匹配
+func buildDeleteRelationQuery(b *relationBatch) (string, map[string]interface{}) {
+	var sb strings.Builder
+	// 使用UNWIND批量处理，MATCH定位关系后删除
+	sb.WriteString("UNWIND $rels AS rel ")
+	sb.WriteString(fmt.Sprintf("MATCH (a:%s { %s: rel.startVal })%s(b:%s { %s: rel.endVal }) ",
+		b.start.table, b.start.fieldMap[b.start.primaryKey],
+		relPattern("r", b.config.Type, b.config.Direction),
+		b.end.table, b.end.fieldMap[b.end.primaryKey]))
+	sb.WriteString("DELETE r")
+	return sb.String(), map[string]interface{}{"rels": b.rels}
+}
+
+// objectGraph 从模型的关系字段中收集到的关联节点和关系
+type objectGraph struct {
+	nodeModels []*Model
+	nodes      map[reflect.Type][]interface{}
+	batches    []*relationBatch
+	batchIndex map[string]*relationBatch
+	visited    map[uintptr]bool
+	mergeRels  bool // 忽略关系字段的 merge 标签，全部使用MERGE
+}
+
+// collectGraph 遍历owners的关系字段，递归收集关联节点和关系，通过指针地址避免循环引用。
+// mergeRels为true时所有关系都使用MERGE写入，重复保存同一对象图不会产生重复的关系
+func (m *Model) collectGraph(owners reflect.Value, mergeRels bool) (*objectGraph, error) {
+	g := &objectGraph{
+		mergeRels:  mergeRels,
+		nodes:      make(map[reflect.Type][]interface{}),
+		batchIndex: make(map[string]*relationBatch),
+		visited:    make(map[uintptr]bool),
+	}
+
+	values := make([]reflect.Value, 0, owners.Len())
+	for i := 0; i < owners.Len(); i++ {
+		v := reflect.ValueOf(owners.Index(i).Interface())
+		g.markVisited(v)
+		values = append(values, v)
+	}
+	for _, v := range values {
+		if err := g.walk(m, v); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// markVisited 记录已处理的指针，返回是否首次访问
+func (g *objectGraph) markVisited(v reflect.Value) bool {
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return true
+	}
+	if g.visited[v.Pointer()] {
+		return false
+	}
+	g.visited[v.Pointer()] = true
+	return true
+}
+
+// walk 处理owner的所有关系字段
+func (g *objectGraph) walk(owner *Model, ownerVal reflect.Value) error {
+	if len(owner.relFields) == 0 {
+		return nil
+	}
+
+	structVal := ownerVal
+	if structVal.Kind() == reflect.Ptr {
+		structVal = structVal.Elem()
+	}
+	var startVal reflect.Value
+	if owner.primaryKey != "" {
+		startVal = structVal.FieldByName(owner.primaryKey)
+	}
+
+	for _, fieldName := range owner.relFields {
+		config := owner.relations[fieldName]
+		if !validDirection(config.Direction) {
+			return fmt.Errorf("%s: invalid direction %q on %s.%s", ErrInvalidModel, config.Direction, owner.modelType, fieldName)
+		}
+
+		field := structVal.FieldByName(fieldName)
+		var targets []reflect.Value
+		switch field.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < field.Len(); i++ {
+				targets = append(targets, field.Index(i))
+			}
+		default:
+			targets = append(targets, field)
+		}
+
+		for _, target := range targets {
+			if target.Kind() == reflect.Interface {
+				target = target.Elem()
+			}
+			if !target.IsValid() || isZeroValue(target) {
+				continue
+			}
+			// 有关联节点时起始节点必须有主键值，否则无法定位
+			if !startVal.IsValid() || isZeroValue(startVal) {
+				return fmt.Errorf("%s: %s has relationships but no primary key value", ErrInvalidModel, owner.modelType)
+			}
+			if err := g.link(owner, startVal.Interface(), config, target); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// link 记录一条关系，并在首次遇到目标节点时将其加入待合并节点并继续递归
+func (g *objectGraph) link(owner *Model, startVal interface{}, config RelationshipConfig, target reflect.Value) error {
+	end := newModel(owner.client, target.Interface())
+	if end.primaryKey == "" {
+		return fmt.Errorf("%s: related model %s has no primary key", ErrInvalidModel, end.modelType)
+	}
+	endVal := getStructKeyValue(target.Interface(), end.primaryKey)
+	if isZeroValue(reflect.ValueOf(endVal)) {
+		return fmt.Errorf("%s: related %s has no primary key value", ErrInvalidModel, end.modelType)
+	}
+
+	if g.mergeRels {
+		config.Merge = true
+	}
+	key := fmt.Sprintf("%s|%s|%s|%s|%t", owner.table, config.Type, end.table, config.Direction, config.Merge)
+	batch, ok := g.batchIndex[key]
+	if !ok {
+		batch = &relationBatch{start: owner, end: end, config: config}
+		g.batchIndex[key] = batch
+		g.batches = append(g.batches, batch)
+	}
+	batch.rels = append(batch.rels, map[string]interface{}{
+		"startVal": startVal,
+		"endVal":   endVal,
+	})
+
+	if !g.markVisited(target) {
+		return nil
+	}
+	if _, ok := g.nodes[end.modelType]; !ok {
+		g.nodeModels = append(g.nodeModels, end)
+	}
+	g.nodes[end.modelType] = append(g.nodes[end.modelType], target.Interface())
+	return g.walk(end, target)
+}
+
+// save 在同一事务中先合并关联节点，再写入关系
+func (g *objectGraph) save(ctx context.Context, tx graphTx) error {
+	for _, model := range g.nodeModels {
+		query, params, err := buildMergeQuery(model, reflect.ValueOf(g.nodes[model.modelType]))
+		if err != nil {
+			return fmt.Errorf("merge related %s failed: %w", model.table, err)
+		}
+		if err := runAndConsume(ctx, tx, query, params); err != nil {
+			return fmt.Errorf("merge related %s failed: %w", model.table, err)
+		}
+	}
+	for _, batch := range g.batches {
+		query, params := buildRelationQuery(batch)
+		if batch.start.debug {
+			fmt.Printf("Executing Relations:\n%s\nWith params: %+v\n", query, params)
+		}
+		if err := runAndConsume(ctx, tx, query, params); err != nil {
+			return fmt.Errorf("create %s relations failed: %w", batch.config.Type, err)
+		}
+	}
+	return nil
+}
+
+// endpoints 获取关系实体的起止节点模型，并校验其标签和主键
+func (m *Model) endpoints() (*Model, *Model, error) {
+	start, end := m.fieldModel(m.startField), m.fieldModel(m.endField)
+	for _, endpoint := range []*Model{start, end} {
+		if endpoint.table == "" || endpoint.primaryKey == "" {
+			return nil, nil, fmt.Errorf("%s: endpoint %s of %s needs a label and a primary key",
+				ErrInvalidModel, endpoint.modelType, m.modelType)
+		}
+	}
+	return start, end, nil
+}
+
+// buildRelationEntityQuery 构建关系实体的批量写入语句。op为CREATE、MERGE或MATCH，
+// tail为定位到关系n之后执行的子句，如 SET n += rel.props 或 DELETE n
+func buildRelationEntityQuery(m *Model, relsValue reflect.Value, op string, tail string) (string, map[string]interface{}, error) {
+	start, end, err := m.endpoints()
+	if err != nil {
+		return "", nil, err
+	}
+
+	rels := make([]map[string]interface{}, 0, relsValue.Len())
+	for i := 0; i < relsValue.Len(); i++ {
+		rel := reflect.ValueOf(relsValue.Index(i).Interface())
+		if rel.Kind() == reflect.Ptr {
+			rel = rel.Elem()
+		}
+
+		startNode, endNode := rel.FieldByName(m.startField), rel.FieldByName(m.endField)
+		if isZeroValue(startNode) || isZeroValue(endNode) {
+			return "", nil, fmt.Errorf("%s: %s requires both %s and %s", ErrInvalidModel, m.modelType, m.startField, m.endField)
+		}
+		props, err := structToProperties(rel.Interface())
+		if err != nil {
+			return "", nil, err
+		}
+		rels = append(rels, map[string]interface{}{
+			"startVal": getStructKeyValue(startNode.Interface(), start.primaryKey),
+			"endVal":   getStructKeyValue(endNode.Interface(), end.primaryKey),
+			"props":    props,
+		})
+	}
+
+	// 关系实体声明了主键时按主键区分起止节点相同的多条关系
+	var pkPattern string
+	if m.primaryKey != "" {
+		pk := m.fieldMap[m.primaryKey]
+		pkPattern = fmt.Sprintf(" { %s: rel.props.%s }", pk, pk)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("UNWIND $rels AS rel ")
+	sb.WriteString(fmt.Sprintf("MATCH (s:%s { %s: rel.startVal }) ", start.table, start.fieldMap[start.primaryKey]))
+	sb.WriteString(fmt.Sprintf("MATCH (e:%s { %s: rel.endVal }) ", end.table, end.fieldMap[end.primaryKey]))
+	sb.WriteString(fmt.Sprintf("%s (s)-[n:%s%s]->(e) ", op, m.table, pkPattern))
+	sb.WriteString(tail)
+
+	params := map[string]interface{}{"rels": rels}
+	if m.debug {
+		fmt.Printf("Executing %s:\n%s\nWith params: %+v\n", op, sb.String(), params)
+	}
+	return sb.String(), params, nil
+}
+
+// assignEndpoints 将查询返回的起止节点映射到关系实体的起止节点字段
+func (m *Model) assignEndpoints(values []interface{}, result reflect.Value) error {
+	for i, field := range []string{m.startField, m.endField} {
+		node, ok := values[i].(*graphNode)
+		if !ok {
+			return fmt.Errorf("query did not return the %s node", field)
+		}
+
+		target := m.fieldModel(field)
+		elem := reflect.New(target.modelType)
+		if err := target.mapToStruct(node.props, elem.Interface()); err != nil {
+			return err
+		}
+
+		fieldVal := result.FieldByName(field)
+		if fieldVal.Kind() == reflect.Ptr {
+			fieldVal.Set(elem)
+		} else {
+			fieldVal.Set(elem.Elem())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type relTestProject struct {
+	ID    string `neo4j:"name=id,primary,label=Project"`
+	Title string `neo4j:"name=title"`
+}
+
+type relTestUser struct {
+	ID       string            `neo4j:"name=id,primary,label=User"`
+	Name     string            `neo4j:"name=name"`
+	Manager  *relTestUser      `neo4j:"rel=REPORTS_TO,direction=outgoing,merge=true"`
+	Friends  []*relTestUser    `neo4j:"rel=FRIENDS,direction=both,merge=true"`
+	Projects []*relTestProject `neo4j:"rel=OWNS"`
+}
+
+func TestParseTagsRelations(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestUser{})
+
+	if m.table != "User" || m.primaryKey != "ID" {
+		t.Fatalf("unexpected model metadata: table=%s primaryKey=%s", m.table, m.primaryKey)
+	}
+	if _, ok := m.fieldMap["Friends"]; ok {
+		t.Errorf("relationship field should not be mapped as property")
+	}
+
+	expected := map[string]RelationshipConfig{
+		"Manager":  {Type: "REPORTS_TO", Direction: Outgoing, Merge: true},
+		"Friends":  {Type: "FRIENDS", Direction: Both, Merge: true},
+		"Projects": {Type: "OWNS", Direction: Outgoing, Merge: false},
+	}
+	for field, config := range expected {
+		if got := m.relations[field]; got != config {
+			t.Errorf("relation %s: expected %+v, got %+v", field, config, got)
+		}
+	}
+
+	props, err := structToProperties(&relTestUser{ID: "U001", Manager: &relTestUser{ID: "M001"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := props["Manager"]; ok {
+		t.Errorf("relationship field should not be written as property: %v", props)
+	}
+}
+
+func TestCollectGraph(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestUser{})
+
+	friend := &relTestUser{ID: "U002"}
+	user := &relTestUser{
+		ID:       "U001",
+		Manager:  &relTestUser{ID: "M001"},
+		Friends:  []*relTestUser{friend, {ID: "U003", Friends: []*relTestUser{friend}}},
+		Projects: []*relTestProject{{ID: "P001"}},
+	}
+	// 循环引用不应导致无限递归
+	friend.Friends = []*relTestUser{user}
+
+	g, err := m.collectGraph(reflect.ValueOf([]*relTestUser{user}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := g.nodes[m.modelType]
+	if len(users) != 3 {
+		t.Errorf("expected 3 related users, got %d", len(users))
+	}
+
+	counts := make(map[string]int)
+	for _, batch := range g.batches {
+		counts[batch.config.Type] += len(batch.rels)
+	}
+	if counts["REPORTS_TO"] != 1 || counts["FRIENDS"] != 4 || counts["OWNS"] != 1 {
+		t.Errorf("unexpected relation counts: %v", counts)
+	}
+
+	// 合并和更新时即使字段没有 merge=true 也使用MERGE
+	for _, mergeRels := range []bool{false, true} {
+		g, err := m.collectGraph(reflect.ValueOf([]*relTestUser{{ID: "U009", Projects: []*relTestProject{{ID: "P009"}}}}), mergeRels)
+		if err != nil {
+			t.Fatal(err)
+		}
+		query, _ := buildRelationQuery(g.batches[0])
+		if merged := strings.Contains(query, "MERGE (a)-[r:OWNS]->(b)"); merged != mergeRels {
+			t.Errorf("mergeRels=%t: unexpected query %q", mergeRels, query)
+		}
+	}
+}
+
+func TestBuildRelationQuery(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestUser{})
+	cases := []struct {
+		config   RelationshipConfig
+		expected string
+	}{
+		{RelationshipConfig{Type: "T", Direction: Outgoing, Merge: true}, "MERGE (a)-[r:T]->(b)"},
+		{RelationshipConfig{Type: "T", Direction: Incoming, Merge: true}, "MERGE (a)<-[r:T]-(b)"},
+		{RelationshipConfig{Type: "T", Direction: Both, Merge: true}, "MERGE (a)-[r:T]-(b)"},
+		{RelationshipConfig{Type: "T", Direction: Both}, "CREATE (a)-[r:T]->(b)"},
+	}
+	for _, c := range cases {
+		query, _ := buildRelationQuery(&relationBatch{start: m, end: m, config: c.config})
+		if !strings.HasSuffix(query, c.expected) {
+			t.Errorf("%+v: expected suffix %q, got %q", c.config, c.expected, query)
+		}
+	}
+}
+
+func TestBuildPreloads(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestUser{}).Preload("Friends", "Manager")
+	if err := m.checkPreloads(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "MATCH (n:User) RETURN n" +
+		", [(n)-[:FRIENDS]-(p0:User) | p0] AS Friends" +
+		", [(n)-[:REPORTS_TO]->(p1:User) | p1] AS Manager "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	var user relTestUser
+	values := []interface{}{
+		[]interface{}{&graphNode{props: map[string]interface{}{"id": "U002"}}, &graphNode{props: map[string]interface{}{"id": "U003"}}},
+		[]interface{}{&graphNode{props: map[string]interface{}{"id": "M001", "name": "Alice"}}},
+	}
+	if err := m.assignPreloads(values, reflect.ValueOf(&user).Elem()); err != nil {
+		t.Fatal(err)
+	}
+	if len(user.Friends) != 2 || user.Friends[1].ID != "U003" {
+		t.Errorf("unexpected friends: %+v", user.Friends)
+	}
+	if user.Manager == nil || user.Manager.Name != "Alice" {
+		t.Errorf("unexpected manager: %+v", user.Manager)
+	}
+
+	if err := newModel(&Client{config: &Config{}}, &relTestUser{}).Preload("Name").checkPreloads(); err == nil {
+		t.Errorf("expected error when preloading a property field")
+	}
+}
+
+type relTestOwnership struct {
+	StartNode *relTestUser `neo4j:"rel=OWNS"`
+	EndNode   *relTestProject
+	Role      string `neo4j:"name=role"`
+	Since     int64  `neo4j:"name=since"`
+}
+
+func TestRelationshipEntity(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestOwnership{})
+	if !m.isRelationship() || m.table != "OWNS" {
+		t.Fatalf("expected relationship entity OWNS, got table=%s start=%s", m.table, m.startField)
+	}
+
+	expected := "MATCH (s:User)-[n:OWNS]->(e:Project) RETURN n, s, e "
+	if query := m.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	rels := []*relTestOwnership{{
+		StartNode: &relTestUser{ID: "U001"},
+		EndNode:   &relTestProject{ID: "P001"},
+		Role:      "Owner",
+	}}
+	query, params, err := buildRelationEntityQuery(m, reflect.ValueOf(rels), "MERGE", "SET n += rel.props")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "UNWIND $rels AS rel MATCH (s:User { id: rel.startVal }) MATCH (e:Project { id: rel.endVal }) " +
+		"MERGE (s)-[n:OWNS]->(e) SET n += rel.props"
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	rel := params["rels"].([]map[string]interface{})[0]
+	props := rel["props"].(map[string]interface{})
+	if rel["startVal"] != "U001" || rel["endVal"] != "P001" || props["role"] != "Owner" || len(props) != 1 {
+		t.Errorf("unexpected params: %+v", rel)
+	}
+
+	var result relTestOwnership
+	rec := &record{values: []interface{}{
+		&graphRelationship{relType: "OWNS", props: map[string]interface{}{"role": "Owner", "since": int64(2020)}},
+		&graphNode{props: map[string]interface{}{"id": "U001"}},
+		&graphNode{props: map[string]interface{}{"id": "P001", "title": "Neo4j ORM"}},
+	}}
+	if err := m.scanRecord(rec, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Role != "Owner" || result.Since != 2020 || result.StartNode.ID != "U001" || result.EndNode.Title != "Neo4j ORM" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRelationsDirection(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestUser{})
+	relations := []Relation{{Start: &relTestUser{ID: "U001"}, End: &relTestUser{ID: "U002"}}}
+
+	batches, err := groupRelations(m, relations, RelationshipConfig{Type: "FRIENDS", Direction: Both, Merge: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	batches[0].mergeNodes = true
+	query, _ := buildRelationQuery(batches[0])
+	expected := "UNWIND $rels AS rel MERGE (a:User { id: rel.startVal }) MERGE (b:User { id: rel.endVal }) MERGE (a)-[r:FRIENDS]-(b)"
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	batches, err = groupRelations(m, relations, RelationshipConfig{Type: "REPORTS_TO", Direction: Incoming})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, _ = buildDeleteRelationQuery(batches[0])
+	expected = "UNWIND $rels AS rel MATCH (a:User { id: rel.startVal })<-[r:REPORTS_TO]-(b:User { id: rel.endVal }) DELETE r"
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	if err := m.CreateRelationsWithConfig(relations, RelationshipConfig{Type: "T", Direction: "sideways"}); err == nil {
+		t.Errorf("expected error for invalid direction")
+	}
+}
+
+func TestGroupRelations(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &relTestUser{})
+	relations := []Relation{
+		{Start: &relTestUser{ID: "U001"}, End: &relTestProject{ID: "P001"}},
+		{Start: &relTestUser{ID: "U001"}, End: &relTestUser{ID: "U002"}, Type: "FRIENDS"},
+		{Start: relTestUser{ID: "U002"}, End: &relTestProject{ID: "P002"}},
+		{Start: &relTestUser{ID: "U003"}, End: &relTestProject{ID: "P001"}, Type: "WATCHES"},
+	}
+
+	batches, err := groupRelations(m, relations, RelationshipConfig{Type: "OWNS", Direction: Outgoing})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		relType string
+		end     string
+		count   int
+	}{{"OWNS", "Project", 2}, {"FRIENDS", "User", 1}, {"WATCHES", "Project", 1}}
+	if len(batches) != len(expected) {
+		t.Fatalf("expected %d groups, got %d", len(expected), len(batches))
+	}
+	for i, e := range expected {
+		b := batches[i]
+		if b.config.Type != e.relType || b.end.table != e.end || len(b.rels) != e.count {
+			t.Errorf("group %d: expected %+v, got type=%s end=%s count=%d", i, e, b.config.Type, b.end.table, len(b.rels))
+		}
+	}
+
+	if _, err := groupRelations(m, relations[:1], RelationshipConfig{}); err == nil {
+		t.Errorf("expected error when relation type is missing")
+	}
+
+	var missing *relTestProject
+	_, err = groupRelations(m, []Relation{{Start: &relTestUser{ID: "U001"}, End: missing}}, RelationshipConfig{Type: "OWNS"})
+	if err == nil || !strings.Contains(err.Error(), ErrInvalidModel) {
+		t.Errorf("expected %s for a typed nil end node, got %v", ErrInvalidModel, err)
+	}
+}
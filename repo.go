@@ -0,0 +1,143 @@
+package neo4jorm
+
+import (
+	"context"
+)
+
+// Repository 泛型仓储，在 Model 之上提供类型安全的查询和写入，结果类型在编译期检查。
+// T必须是模型的结构体类型（如 Product 而不是 *Product），否则所有操作返回 ErrInvalidModel。
+// 条件方法返回新的仓储，原仓储可以重复使用
+type Repository[T any] struct {
+	model *Model
+}
+
+// Repo 创建T的仓储，T为结构体类型，如 neo4jorm.Repo[Product](client)
+func Repo[T any](client *Client) *Repository[T] {
+	var zero T
+	return &Repository[T]{model: newModel(client, &zero)}
+}
+
+// TxRepo 创建在事务中执行的仓储
+func TxRepo[T any](tx *Transaction) *Repository[T] {
+	var zero T
+	return &Repository[T]{model: tx.Model(&zero)}
+}
+
+// Model 返回底层模型的副本，用于仓储没有提供的操作
+func (r *Repository[T]) Model() *Model {
+	return r.model.clone()
+}
+
+// with 在模型副本上添加查询条件，返回新的仓储
+func (r *Repository[T]) with(build func(m *Model) *Model) *Repository[T] {
+	return &Repository[T]{model: build(r.model.clone())}
+}
+
+// Where 添加查询条件，参数与 Model.Where 相同
+func (r *Repository[T]) Where(condition interface{}, args ...interface{}) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Where(condition, args...) })
+}
+
+// Or 参数与 Model.Or 相同
+func (r *Repository[T]) Or(condition interface{}, args ...interface{}) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Or(condition, args...) })
+}
+
+// Not 参数与 Model.Not 相同
+func (r *Repository[T]) Not(condition interface{}, args ...interface{}) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Not(condition, args...) })
+}
+
+// OrderBy 参数与 Model.OrderBy 相同
+func (r *Repository[T]) OrderBy(fields ...string) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.OrderBy(fields...) })
+}
+
+// Limit 设置结果数量限制
+func (r *Repository[T]) Limit(limit int) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Limit(limit) })
+}
+
+// Offset 设置跳过的结果数量
+func (r *Repository[T]) Offset(offset int) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Offset(offset) })
+}
+
+// Preload 预加载关系字段
+func (r *Repository[T]) Preload(fields ...string) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Preload(fields...) })
+}
+
+// Select 只返回指定的属性
+func (r *Repository[T]) Select(fields ...string) *Repository[T] {
+	return r.with(func(m *Model) *Model { return m.Select(fields...) })
+}
+
+// Find 查询全部满足条件的结果
+func (r *Repository[T]) Find(ctx context.Context) ([]T, error) {
+	var results []T
+	if err := r.model.WithContext(ctx).Find(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// First 查询第一个满足条件的结果，没有结果时返回错误
+func (r *Repository[T]) First(ctx context.Context) (T, error) {
+	var result T
+	err := r.model.WithContext(ctx).FindOne(&result)
+	return result, err
+}
+
+// Count 统计满足条件的结果数量
+func (r *Repository[T]) Count(ctx context.Context) (int64, error) {
+	return r.model.WithContext(ctx).Count()
+}
+
+// Exists 是否存在满足条件的结果
+func (r *Repository[T]) Exists(ctx context.Context) (bool, error) {
+	return r.model.WithContext(ctx).Exists()
+}
+
+// Paginate 分页查询，page从1开始
+func (r *Repository[T]) Paginate(ctx context.Context, page, size int) ([]T, *Page, error) {
+	var results []T
+	p, err := r.model.WithContext(ctx).Paginate(page, size, &results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, p, nil
+}
+
+// FindInBatches 按主键顺序分批查询，每批最多size条，fn返回错误时停止并返回该错误
+func (r *Repository[T]) FindInBatches(ctx context.Context, size int, fn func(batch []T) error) error {
+	var results []T
+	return r.model.WithContext(ctx).FindInBatches(&results, size, func(int) error {
+		return fn(results)
+	})
+}
+
+// Create 创建节点
+func (r *Repository[T]) Create(ctx context.Context, node *T) error {
+	return r.model.WithContext(ctx).CreateOne(node)
+}
+
+// CreateBatch 批量创建节点
+func (r *Repository[T]) CreateBatch(ctx context.Context, nodes []T) error {
+	return r.model.WithContext(ctx).CreateBatch(nodes)
+}
+
+// Merge 批量合并节点（存在则更新，不存在则创建）
+func (r *Repository[T]) Merge(ctx context.Context, nodes []T) error {
+	return r.model.WithContext(ctx).MergeBatch(nodes)
+}
+
+// Update 按主键更新节点
+func (r *Repository[T]) Update(ctx context.Context, node *T) error {
+	return r.model.WithContext(ctx).Update(node)
+}
+
+// Delete 删除节点
+func (r *Repository[T]) Delete(ctx context.Context, nodes ...*T) error {
+	return r.model.WithContext(ctx).DeleteBatch(nodes)
+}
@@ -0,0 +1,101 @@
+package neo4jorm
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepo(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		return []*record{
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "A", "price": 3.0}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "B", "price": 4.0}}}},
+		}
+	}}
+	repo := Repo[condTestProduct](&Client{driver: driver, config: &Config{}})
+	ctx := context.Background()
+
+	cheap := repo.Where(Lt("Price", 5)).OrderBy("Price")
+	products, err := cheap.Find(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 2 || products[1].SKU != "B" {
+		t.Errorf("unexpected products: %+v", products)
+	}
+	first, err := cheap.Limit(1).First(ctx)
+	if err != nil || first.SKU != "A" {
+		t.Errorf("unexpected first: %+v, %v", first, err)
+	}
+	if _, err := repo.Find(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, &condTestProduct{SKU: "C"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"MATCH (n:Product) WHERE n.price < $price_0 RETURN n  ORDER BY n.price",
+		"MATCH (n:Product) WHERE n.price < $price_0 RETURN n  ORDER BY n.price LIMIT 1",
+		"MATCH (n:Product) RETURN n ",
+		"UNWIND $nodes AS node CREATE (n:Product) SET n += node.props ",
+	}
+	if !reflect.DeepEqual(driver.queries, expected) {
+		t.Errorf("expected queries %q, got %q", expected, driver.queries)
+	}
+}
+
+func TestRepoPointerType(t *testing.T) {
+	driver := &fakeDriver{results: func(string) []*record { return nil }}
+	repo := Repo[*condTestProduct](&Client{driver: driver, config: &Config{}})
+	ctx := context.Background()
+
+	if _, err := repo.Where(Eq("SKU", "A")).Find(ctx); err == nil {
+		t.Errorf("expected Find to fail for a pointer type parameter")
+	}
+	if _, err := repo.Count(ctx); err == nil {
+		t.Errorf("expected Count to fail for a pointer type parameter")
+	}
+	product := &condTestProduct{SKU: "A"}
+	if err := repo.Create(ctx, &product); err == nil {
+		t.Errorf("expected Create to fail for a pointer type parameter")
+	}
+	if err := repo.Delete(ctx, &product); err == nil {
+		t.Errorf("expected Delete to fail for a pointer type parameter")
+	}
+	if len(driver.queries) != 0 {
+		t.Errorf("expected no queries, got %q", driver.queries)
+	}
+}
+
+func TestNonStructModel(t *testing.T) {
+	driver := newFakeDriver()
+	m := newModel(&Client{driver: driver, config: &Config{}}, 42)
+	rels := []Relation{{Start: &condTestProduct{SKU: "A"}, End: &condTestProduct{SKU: "B"}}}
+
+	writes := map[string]func() error{
+		"CreateOne":       func() error { return m.CreateOne(42) },
+		"MergeOne":        func() error { return m.MergeOne(42) },
+		"Update":          func() error { return m.Update(42) },
+		"DeleteBatch":     func() error { return m.DeleteBatch([]int{1}) },
+		"CreateRelations": func() error { return m.CreateRelations(rels, "LINKS") },
+		"DeleteRelations": func() error { return m.DeleteRelations(rels, "LINKS") },
+	}
+	for name, write := range writes {
+		if err := write(); err == nil || !strings.Contains(err.Error(), ErrInvalidModel) {
+			t.Errorf("%s: expected %s, got %v", name, ErrInvalidModel, err)
+		}
+	}
+
+	// 查询清理状态后错误仍然保留
+	for i := 0; i < 2; i++ {
+		if err := m.Find(&[]int{}); err == nil {
+			t.Errorf("expected Find #%d to fail", i+1)
+		}
+	}
+	if len(driver.queries) != 0 || len(driver.sessions) != 0 {
+		t.Errorf("expected no queries, got %q", driver.queries)
+	}
+}
@@ -0,0 +1,223 @@
+package neo4jorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var pathType = reflect.TypeOf(Path{})
+
+// scanRecords 将查询结果按列映射到dest。dest为切片指针时每条记录对应一个元素：
+// 结构体按列名匹配字段，map[string]interface{} 保存全部列，其他类型取第一列；
+// dest为结构体、map或标量的指针时只取第一条记录，没有记录时返回错误。
+// 节点、关系和路径映射为对应的模型结构体、Path，client用于解析模型
+func scanRecords(client *Client, records []*record, dest interface{}) error {
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return errors.New("output must be a pointer")
+	}
+	destVal = destVal.Elem()
+
+	if destVal.Kind() != reflect.Slice {
+		if len(records) == 0 {
+			return errors.New("no records found")
+		}
+		return scanRow(client, records[0], destVal)
+	}
+
+	for _, rec := range records {
+		elem := reflect.New(destVal.Type().Elem()).Elem()
+		if err := scanRow(client, rec, elem); err != nil {
+			return err
+		}
+		destVal.Set(reflect.Append(destVal, elem))
+	}
+	return nil
+}
+
+// scanRow 将一条记录映射到可赋值的elem
+func scanRow(client *Client, rec *record, elem reflect.Value) error {
+	if len(rec.values) == 0 {
+		return errors.New("record has no columns")
+	}
+
+	switch elem.Kind() {
+	case reflect.Ptr:
+		if elem.Type().Elem().Kind() != reflect.Struct {
+			break
+		}
+		ptr := reflect.New(elem.Type().Elem())
+		if err := scanRow(client, rec, ptr.Elem()); err != nil {
+			return err
+		}
+		elem.Set(ptr)
+		return nil
+	case reflect.Struct:
+		if elem.Type() == pathType {
+			break
+		}
+		// 只有一列节点或关系，且没有同名字段时映射整个节点
+		if _, ok := columnField(elem.Type(), rec.keys[0]); !ok && len(rec.values) == 1 && isGraphEntity(rec.values[0]) {
+			return scanValue(client, elem, rec.values[0], rec.keys[0])
+		}
+		for i, key := range rec.keys {
+			index, ok := columnField(elem.Type(), key)
+			if !ok {
+				continue
+			}
+			if err := scanValue(client, elem.Field(index), rec.values[i], key); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if elem.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", elem.Type())
+		}
+		row := reflect.MakeMapWithSize(elem.Type(), len(rec.keys))
+		for i, key := range rec.keys {
+			value := reflect.New(elem.Type().Elem()).Elem()
+			if err := scanValue(client, value, rec.values[i], key); err != nil {
+				return err
+			}
+			row.SetMapIndex(reflect.ValueOf(key).Convert(elem.Type().Key()), value)
+		}
+		elem.Set(row)
+		return nil
+	}
+
+	name := ""
+	if len(rec.keys) > 0 {
+		name = rec.keys[0]
+	}
+	return scanValue(client, elem, rec.values[0], name)
+}
+
+// scanValue 将一列的值映射到dst：节点和关系映射为结构体（字段为 interface{} 时按标签或关系类型
+// 映射为已注册模型），路径映射为 Path，列表逐个元素映射，其他值按字段相同的规则转换
+func scanValue(client *Client, dst reflect.Value, value interface{}, name string) error {
+	switch v := value.(type) {
+	case *graphNode, *graphRelationship:
+		if dst.Kind() == reflect.Interface {
+			converted, err := toGraphValue(v)
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(converted))
+			return nil
+		}
+
+		t := dst.Type()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("column %s: can not scan %T into %s", name, value, dst.Type())
+		}
+		m := resultModel(client, t)
+		props := graphProps(v)
+		ptr := reflect.New(t)
+		if err := m.mapToStruct(props, ptr.Interface()); err != nil {
+			return err
+		}
+		if dst.Kind() == reflect.Ptr {
+			dst.Set(ptr)
+		} else {
+			dst.Set(ptr.Elem())
+		}
+		return nil
+	case *graphPath:
+		path, err := toPath(v)
+		if err != nil {
+			return err
+		}
+		switch {
+		case dst.Type() == pathType:
+			dst.Set(reflect.ValueOf(path))
+		case dst.Type() == reflect.PtrTo(pathType):
+			dst.Set(reflect.ValueOf(&path))
+		case dst.Kind() == reflect.Interface:
+			dst.Set(reflect.ValueOf(path))
+		default:
+			return fmt.Errorf("column %s: can not scan path into %s", name, dst.Type())
+		}
+		return nil
+	case []interface{}:
+		if dst.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(dst.Type(), len(v), len(v))
+			for i, item := range v {
+				if err := scanValue(client, slice.Index(i), item, name); err != nil {
+					return err
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+		if dst.Kind() == reflect.Interface {
+			items := make([]interface{}, len(v))
+			for i, item := range v {
+				if err := scanValue(client, reflect.ValueOf(&items[i]).Elem(), item, name); err != nil {
+					return err
+				}
+			}
+			dst.Set(reflect.ValueOf(items))
+			return nil
+		}
+	}
+	return assignValue(dst, value, name)
+}
+
+// toGraphValue 将节点或关系映射为已注册模型，未注册的映射为 *Node、*Edge
+func toGraphValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *graphNode:
+		return toNode(v)
+	case *graphRelationship:
+		return toRelationship(v, nil)
+	}
+	return value, nil
+}
+
+// isGraphEntity 是否为节点或关系
+func isGraphEntity(value interface{}) bool {
+	switch value.(type) {
+	case *graphNode, *graphRelationship:
+		return true
+	}
+	return false
+}
+
+// graphProps 节点或关系的属性
+func graphProps(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case *graphNode:
+		return v.props
+	case *graphRelationship:
+		return v.props
+	}
+	return nil
+}
+
+// columnField 按列名查找结构体字段：依次匹配字段名、neo4j 标签中的 name，再忽略大小写匹配
+func columnField(t reflect.Type, column string) (int, bool) {
+	for _, exact := range []bool{true, false} {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			names := []string{field.Name}
+			if name := parseTag(field.Tag.Get(tagName))[tagkey]; name != "" {
+				names = append(names, name)
+			}
+			for _, name := range names {
+				if exact && name == column || !exact && strings.EqualFold(name, column) {
+					return i, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,163 @@
+package neo4jorm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Select 指定返回的列，每一项为字段名或表达式，可以用 AS 指定列名，如
+// Select("Category", "count(*) AS Total", "avg(Price) AS AvgPrice")。
+// 字段名映射为 n.<属性名> 并以属性名作为列名，表达式中的字段名同样会被替换。
+// Find 时只返回选择的属性，按列名映射到模型的属性字段，用 AS 重命名的属性仍映射到原字段，未选择的字段保持零值
+func (m *Model) Select(fields ...string) *Model {
+	m.selects = append(m.selects, fields...)
+	return m
+}
+
+// GroupBy 指定分组字段。Cypher 按 RETURN 中的非聚合列隐式分组，
+// 分组字段没有出现在 Select 中时会自动加入返回的列
+func (m *Model) GroupBy(fields ...string) *Model {
+	m.groupBy = append(m.groupBy, fields...)
+	return m
+}
+
+// Scan 执行 Select 指定列的查询，按列名将结果映射到dest，dest可以是任意结构体、
+// map[string]interface{} 或标量的切片指针，也可以是单个结构体的指针
+func (m *Model) Scan(dest interface{}) error {
+	defer m.cleanQuery()
+	if len(m.selects) == 0 {
+		return errors.New("Scan requires Select")
+	}
+
+	columns := m.projection()
+	query := m.buildQueryReturning(strings.Join(columns, ", "))
+	if m.err != nil {
+		return m.err
+	}
+	if m.debug {
+		fmt.Printf("Executing Query:\n%s\nWith params: %+v\n", query, m.params)
+	}
+
+	records, err := m.query(query, m.params)
+	if err != nil {
+		return err
+	}
+	return scanRecords(m.client, records, dest)
+}
+
+// Pluck 查询单个字段的值，out为切片指针，如 Pluck("SKU", &skus)
+func (m *Model) Pluck(field string, out interface{}) error {
+	m.selects = []string{field}
+	return m.Scan(out)
+}
+
+// selectColumn 选择的一列
+type selectColumn struct {
+	expr  string
+	alias string
+}
+
+func (c selectColumn) String() string {
+	if c.alias == "" {
+		return c.expr
+	}
+	return c.expr + " AS " + c.alias
+}
+
+// projection 解析 Select 和 GroupBy 生成 RETURN 的列
+func (m *Model) projection() []string {
+	if len(m.hops) > 0 {
+		m.setErr(errors.New("Select is not supported on traverse queries"))
+		return nil
+	}
+
+	columns := m.selectColumns()
+	for _, field := range m.groupBy {
+		column, err := m.resolveColumn(field)
+		if err != nil {
+			m.setErr(err)
+			return nil
+		}
+		found := false
+		for _, c := range columns {
+			found = found || c.expr == column.expr
+		}
+		if !found {
+			columns = append(columns, column)
+		}
+	}
+
+	result := make([]string, 0, len(columns))
+	for _, c := range columns {
+		result = append(result, c.String())
+	}
+	return result
+}
+
+// selectColumns 解析 Select 的各列，解析失败时记录错误
+func (m *Model) selectColumns() []selectColumn {
+	columns := make([]selectColumn, 0, len(m.selects))
+	for _, item := range m.selects {
+		column, err := m.resolveColumn(item)
+		if err != nil {
+			m.setErr(err)
+			return nil
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// resolveColumn 解析一列：字段名映射为 n.<属性名> AS <属性名>，表达式替换其中的字段名
+func (m *Model) resolveColumn(item string) (selectColumn, error) {
+	expr, alias := splitAlias(item)
+	if prop, err := m.propertyName(expr); err == nil {
+		if alias == "" {
+			alias = prop
+		}
+		return selectColumn{expr: "n." + prop, alias: alias}, nil
+	}
+
+	rewritten, err := m.rewriteFields(expr, "n")
+	if err != nil {
+		return selectColumn{}, err
+	}
+	if strings.Contains(rewritten, placeholder) {
+		return selectColumn{}, fmt.Errorf("select %q: placeholders are not supported", item)
+	}
+	return selectColumn{expr: rewritten, alias: alias}, nil
+}
+
+// selectedAlias 返回已选择的属性对应的列名，排序时在聚合查询中必须引用列名
+func (m *Model) selectedAlias(prop string) (string, bool) {
+	for _, item := range append(append([]string(nil), m.selects...), m.groupBy...) {
+		column, err := m.resolveColumn(item)
+		if err == nil && column.expr == "n."+prop && column.alias != "" {
+			return column.alias, true
+		}
+	}
+	return "", false
+}
+
+// selectedProps 返回用 AS 重命名的属性列的列名到属性名的映射，Find 按属性名把这些列映射回模型的字段
+func (m *Model) selectedProps() map[string]string {
+	props := make(map[string]string)
+	for _, item := range m.selects {
+		expr, alias := splitAlias(item)
+		if prop, err := m.propertyName(expr); err == nil && alias != "" {
+			props[alias] = prop
+		}
+	}
+	return props
+}
+
+// splitAlias 拆分 "expr AS alias"
+func splitAlias(item string) (string, string) {
+	item = strings.TrimSpace(item)
+	upper := strings.ToUpper(item)
+	if i := strings.LastIndex(upper, " AS "); i >= 0 {
+		return strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+4:])
+	}
+	return item, ""
+}
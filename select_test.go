@@ -0,0 +1,114 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+type categoryStats struct {
+	Category string
+	Total    int
+	AvgPrice float64 `neo4j:"name=avg_price"`
+}
+
+func TestSelectGroupByScan(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		keys := []string{"Category", "Total", "avg_price"}
+		return []*record{
+			{keys: keys, values: []interface{}{"book", int64(3), 12.5}},
+			{keys: keys, values: []interface{}{"music", int64(1), nil}},
+		}
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+
+	var stats []categoryStats
+	err := m.Where(Gt("Price", 1)).
+		Select("Name AS Category", "count(*) AS Total", "avg(Price) AS avg_price").
+		GroupBy("Name").
+		OrderBy("Total DESC", "Name").
+		Scan(&stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "MATCH (n:Product) WHERE n.price > $price_0 " +
+		"RETURN n.product_name AS Category, count(*) AS Total, avg(n.price) AS avg_price  ORDER BY Total DESC, Category"
+	if driver.queries[0] != expected {
+		t.Errorf("expected %q, got %q", expected, driver.queries[0])
+	}
+	want := []categoryStats{{Category: "book", Total: 3, AvgPrice: 12.5}, {Category: "music", Total: 1}}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+
+	var rows []map[string]interface{}
+	if err := m.Select("SKU").GroupBy("Name").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	expected = "MATCH (n:Product) RETURN n.sku AS sku, n.product_name AS product_name "
+	if driver.queries[1] != expected {
+		t.Errorf("expected %q, got %q", expected, driver.queries[1])
+	}
+	if len(rows) != 2 || rows[0]["Total"] != int64(3) {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	if err := m.Scan(&rows); err == nil {
+		t.Errorf("expected error when Scan is called without Select")
+	}
+}
+
+func TestSelectProjection(t *testing.T) {
+	driver := &fakeDriver{results: func(query string) []*record {
+		keys := []string{"sku", "price"}
+		return []*record{
+			{keys: keys, values: []interface{}{"A", int64(3)}},
+			{keys: keys, values: []interface{}{"B", 4.5}},
+		}
+	}}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &condTestProduct{})
+
+	var products []condTestProduct
+	if err := m.Select("SKU", "Price").Where(Gt("Price", 1)).Find(&products); err != nil {
+		t.Fatal(err)
+	}
+	want := []condTestProduct{{SKU: "A", Price: 3}, {SKU: "B", Price: 4.5}}
+	if !reflect.DeepEqual(products, want) {
+		t.Errorf("expected %+v, got %+v", want, products)
+	}
+
+	var skus []string
+	if err := m.Pluck("SKU", &skus); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(skus, []string{"A", "B"}) {
+		t.Errorf("unexpected skus: %v", skus)
+	}
+
+	// 用 AS 重命名的属性列按属性名映射回模型的字段
+	renamed := &fakeDriver{results: func(query string) []*record {
+		return []*record{{keys: []string{"title", "cost"}, values: []interface{}{"graph", 2.5}}}
+	}}
+	products = nil
+	err := newModel(&Client{driver: renamed, config: &Config{}}, &condTestProduct{}).
+		Select("Name AS title", "Price AS cost").Find(&products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []condTestProduct{{Name: "graph", Price: 2.5}}; !reflect.DeepEqual(products, want) {
+		t.Errorf("expected %+v, got %+v", want, products)
+	}
+
+	own := newModel(&Client{config: &Config{}}, &relTestOwnership{}).Select("Role")
+	expected := "MATCH (s:User)-[n:OWNS]->(e:Project) RETURN n.role AS role, s, e "
+	if query := own.buildQuery(); query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	queries := []string{
+		"MATCH (n:Product) WHERE n.price > $price_0 RETURN n.sku AS sku, n.price AS price ",
+		"MATCH (n:Product) RETURN n.sku AS sku ",
+	}
+	if !reflect.DeepEqual(driver.queries, queries) {
+		t.Errorf("expected queries %q, got %q", queries, driver.queries)
+	}
+}
@@ -0,0 +1,198 @@
+package neo4jorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// Rows 流式读取查询结果的游标，记录在 Next 时才从驱动拉取，使用完必须调用 Close 释放会话
+type Rows struct {
+	model   *Model // 映射结果使用的模型
+	ctx     context.Context
+	session graphSession // 绑定了用户事务时为空
+	result  graphResult
+	current *record
+	err     error
+	closed  bool
+}
+
+// Rows 执行查询并返回流式游标，查询条件、排序和 After 游标与 Find 相同。未绑定用户事务时游标独占一个会话，
+// 直到读取完毕或 Close。遍历查询的终点节点按 Scan 的目标类型映射，执行时不知道结果类型，
+// 因此不按终点标签过滤，需要过滤时使用 Each 或 Iter
+func (m *Model) Rows() (*Rows, error) {
+	defer m.cleanQuery()
+	if err := m.checkPreloads(); err != nil {
+		return nil, err
+	}
+	// 与 Find 相同，使用 After 时按游标排序键排序并只返回游标之后的结果
+	m.applyKeyset()
+	query := m.buildQuery()
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.debug {
+		fmt.Printf("Executing Query:\n%s\nWith params: %+v\n", query, m.params)
+	}
+
+	// 清理查询条件前保存映射结果需要的模型，遍历查询映射到终点节点的模型
+	model := m.clone()
+	if m.target != nil {
+		model = m.target
+	}
+	rows := &Rows{model: model, ctx: m.context()}
+	if m.tx != nil {
+		result, err := m.tx.tx.run(rows.ctx, query, m.params)
+		if err != nil {
+			return nil, err
+		}
+		rows.result = result
+		return rows, nil
+	}
+
+	rows.session = m.client.newSession(rows.ctx)
+	result, err := rows.session.run(rows.ctx, query, m.params)
+	if err != nil {
+		rows.session.close(context.Background())
+		return nil, err
+	}
+	rows.result = result
+	return rows, nil
+}
+
+// Next 读取下一条记录，没有更多记录或出错时返回false并自动关闭游标，错误通过 Err 获取
+func (r *Rows) Next() bool {
+	if r.closed {
+		return false
+	}
+	if r.result.next(r.ctx) {
+		r.current = r.result.record()
+		return true
+	}
+	r.current = nil
+	r.err = r.result.err()
+	r.Close()
+	return false
+}
+
+// Scan 将当前记录映射到dest，dest为模型结构体指针，也可以是其他结构体、map或标量的指针
+func (r *Rows) Scan(dest interface{}) error {
+	if r.current == nil {
+		return errors.New("Scan called without a successful Next")
+	}
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return errors.New("output must be a pointer")
+	}
+	// 遍历查询执行时还不知道结果类型，与 Find 一样按dest的类型确定终点节点的模型
+	model := r.model
+	if len(model.hops) > 0 {
+		model.bindTarget(dest)
+		if model.err != nil {
+			return model.err
+		}
+		if model.target != nil {
+			model = model.target
+		}
+	}
+	if destVal.Elem().Type() == model.modelType {
+		return model.scanRecord(r.current, dest)
+	}
+	return scanRow(model.client, r.current, destVal.Elem())
+}
+
+// Err 返回遍历过程中的错误
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close 关闭游标并释放会话，可以重复调用
+func (r *Rows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	err := r.result.consume(r.ctx)
+	if r.session != nil {
+		if closeErr := r.session.close(context.Background()); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Each 逐条读取查询结果并调用fn，fn的类型为 func(*T) error，T为模型（遍历查询时为终点节点的模型）。
+// fn返回错误时停止读取并返回该错误
+func (m *Model) Each(fn interface{}) error {
+	fnVal := reflect.ValueOf(fn)
+	fnType := fnVal.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 1 || fnType.NumOut() != 1 ||
+		fnType.In(0).Kind() != reflect.Ptr || fnType.Out(0) != errorType {
+		m.cleanQuery()
+		return fmt.Errorf("Each: expected func(*T) error, got %T", fn)
+	}
+
+	elemType := fnType.In(0).Elem()
+	m.bindTarget(reflect.New(fnType.In(0)).Interface())
+	rows, err := m.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		if err := rows.Scan(elem.Interface()); err != nil {
+			return err
+		}
+		if err, _ := fnVal.Call([]reflect.Value{elem})[0].Interface().(error); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Iter 返回可用于 range 的查询结果迭代器，如 for p, err := range neo4jorm.Iter[Product](m)。
+// 查询在开始遍历时执行，跳出循环时自动关闭游标；迭代器只能遍历一次
+func Iter[T any](m *Model) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		m.bindTarget(&zero)
+		rows, err := m.Rows()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var v T
+			if err := rows.Scan(&v); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Each 逐条读取查询结果并调用fn，fn返回错误时停止并返回该错误
+func (r *Repository[T]) Each(ctx context.Context, fn func(*T) error) error {
+	return r.model.WithContext(ctx).Each(fn)
+}
+
+// Iter 返回可用于 range 的查询结果迭代器，每次遍历都会重新执行查询
+func (r *Repository[T]) Iter(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		Iter[T](r.model.WithContext(ctx))(yield)
+	}
+}
@@ -0,0 +1,117 @@
+package neo4jorm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStreaming(t *testing.T) {
+	products := func(query string) []*record {
+		return []*record{
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "A", "price": 1.0}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "B", "price": 2.0}}}},
+			{values: []interface{}{&graphNode{props: map[string]interface{}{"sku": "C", "price": 3.0}}}},
+		}
+	}
+	driver := &fakeDriver{results: products}
+	client := &Client{driver: driver, config: &Config{}}
+
+	rows, err := newModel(client, &condTestProduct{}).Where(Gt("Price", 0)).Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var skus []string
+	for rows.Next() {
+		var p condTestProduct
+		if err := rows.Scan(&p); err != nil {
+			t.Fatal(err)
+		}
+		skus = append(skus, p.SKU)
+	}
+	if rows.Err() != nil || len(skus) != 3 || skus[2] != "C" {
+		t.Errorf("unexpected rows: %v, %v", skus, rows.Err())
+	}
+	if !driver.lastSession().closed {
+		t.Errorf("expected session to be closed after the last row")
+	}
+	expected := "MATCH (n:Product) WHERE n.price > $price_0 RETURN n "
+	if driver.queries[0] != expected {
+		t.Errorf("expected %q, got %q", expected, driver.queries[0])
+	}
+
+	// After 游标同样生效
+	cursor, err := encodeCursor([]keysetKey{{field: "SKU", prop: "sku"}}, []string{"SKU"}, reflect.ValueOf(condTestProduct{SKU: "A"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = newModel(client, &condTestProduct{}).After(cursor).Limit(2).Each(func(p *condTestProduct) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "MATCH (n:Product) WHERE ((n.sku > $sku_0)) RETURN n  ORDER BY n.sku LIMIT 2"
+	if last := driver.queries[len(driver.queries)-1]; last != expected {
+		t.Errorf("expected %q, got %q", expected, last)
+	}
+
+	// 遍历查询按 Scan 的目标类型映射终点节点
+	driver.results = func(string) []*record {
+		return []*record{{keys: []string{"t0"}, values: []interface{}{
+			&graphNode{labels: []string{"Project"}, props: map[string]interface{}{"id": "P001", "title": "graph"}},
+		}}}
+	}
+	rows, err = newModel(client, &relTestUser{}).Traverse("OWNS", Outgoing).Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var projects []relTestProject
+	for rows.Next() {
+		var p relTestProject
+		if err := rows.Scan(&p); err != nil {
+			t.Fatal(err)
+		}
+		projects = append(projects, p)
+	}
+	if len(projects) != 1 || projects[0].ID != "P001" || projects[0].Title != "graph" {
+		t.Errorf("unexpected projects: %+v, %v", projects, rows.Err())
+	}
+	rows, err = newModel(client, &relTestUser{}).Traverse("OWNS", Outgoing).Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var unlabeled struct{ ID string }
+	if !rows.Next() || rows.Scan(&unlabeled) == nil {
+		t.Errorf("expected an error for an unlabeled traverse target")
+	}
+	rows.Close()
+	driver.results = products
+
+	stop := errors.New("stop")
+	skus = nil
+	err = newModel(client, &condTestProduct{}).Each(func(p *condTestProduct) error {
+		skus = append(skus, p.SKU)
+		if p.SKU == "B" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || len(skus) != 2 || !driver.lastSession().closed {
+		t.Errorf("expected Each to stop at B and close the session, got %v, %v", skus, err)
+	}
+	if err := newModel(client, &condTestProduct{}).Each(func(p condTestProduct) {}); err == nil {
+		t.Errorf("expected error for invalid callback")
+	}
+
+	skus = nil
+	for p, err := range Repo[condTestProduct](client).Iter(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		skus = append(skus, p.SKU)
+		break
+	}
+	if len(skus) != 1 || !driver.lastSession().closed {
+		t.Errorf("expected iteration to stop after one row and close the session, got %v", skus)
+	}
+}
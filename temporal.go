@@ -0,0 +1,121 @@
+package neo4jorm
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// 时间字段的存储类型，通过 type 标签指定，如 `neo4j:"name=birthday,type=date"`。
+// 未指定时 time.Time 存储为带时区的 datetime
+const (
+	storageDate          = "date"
+	storageDateTime      = "datetime"
+	storageLocalDateTime = "localdatetime"
+)
+
+// averageMonth Neo4j 换算时长时一个月的平均长度（365.2425天/12）
+const averageMonth = 2629746 * time.Second
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// graphTemporal 指定了存储类型的时间值，由驱动转换为对应的 Neo4j 时间类型
+type graphTemporal struct {
+	storage string
+	t       time.Time
+}
+
+// toPropertyValue 将字段值转换为写入数据库的值：指针取指向的值，time.Time 按storage
+// 转换为 date、datetime 或 localdatetime。time.Duration 由驱动转换为 duration
+func toPropertyValue(value interface{}, storage string) (interface{}, error) {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		value = rv.Elem().Interface()
+	}
+
+	t, ok := value.(time.Time)
+	if !ok {
+		if storage != "" {
+			return nil, fmt.Errorf("type=%s requires a time.Time field, got %T", storage, value)
+		}
+		return value, nil
+	}
+	switch storage {
+	case "", storageDateTime:
+		return t, nil
+	case storageDate, storageLocalDateTime:
+		return graphTemporal{storage: storage, t: t}, nil
+	}
+	return nil, fmt.Errorf("unsupported temporal type %q", storage)
+}
+
+// propertyValue 按属性对应字段的类型和 type 标签转换查询参数：游标中的时间字符串解析为 time.Time，
+// 整数转换为 time.Duration；切片（In 条件）逐个元素转换。不是模型字段的属性原样返回
+func (m *Model) propertyValue(prop string, value interface{}) (interface{}, error) {
+	field, ok := m.modelType.FieldByName(m.fieldName(prop))
+	if !ok || value == nil {
+		return value, nil
+	}
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	storage := parseTag(field.Tag.Get(tagName))[tagType]
+
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && (fieldType == timeType || fieldType == durationType) {
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			item, err := m.propertyValue(prop, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+
+	switch fieldType {
+	case timeType:
+		if s, ok := value.(string); ok {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid time %q: %w", field.Name, s, err)
+			}
+			value = t
+		}
+		return toPropertyValue(value, storage)
+	case durationType:
+		if d, ok := convertToInt(rv); ok && rv.Type() != durationType {
+			value = time.Duration(d)
+		}
+	}
+	return toPropertyValue(value, "")
+}
+
+// dateOf 取时间在其时区中的日期，以 UTC 零点表示
+func dateOf(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
+
+// splitDuration 将时长拆分为秒和非负的纳秒
+func splitDuration(d time.Duration) (int64, int) {
+	seconds, nanos := int64(d/time.Second), int(d%time.Second)
+	if nanos < 0 {
+		seconds--
+		nanos += int(time.Second)
+	}
+	return seconds, nanos
+}
+
+// durationOf 将 Neo4j 时长转换为 time.Duration，月按平均长度、天按24小时换算
+func durationOf(months, days, seconds int64, nanos int) time.Duration {
+	return time.Duration(months)*averageMonth + time.Duration(days)*24*time.Hour +
+		time.Duration(seconds)*time.Second + time.Duration(nanos)
+}
@@ -0,0 +1,96 @@
+package neo4jorm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type temporalTestEvent struct {
+	ID       string         `neo4j:"name=id,primary,label=Event"`
+	Day      time.Time      `neo4j:"name=day,type=date"`
+	Local    time.Time      `neo4j:"name=local,type=localdatetime"`
+	At       *time.Time     `neo4j:"name=at"`
+	Timeout  time.Duration  `neo4j:"name=timeout"`
+	Deadline *time.Duration `neo4j:"name=deadline"`
+}
+
+func TestTemporalProperties(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	props, err := structToProperties(&temporalTestEvent{ID: "E1", Day: at, Local: at, At: &at, Timeout: 90 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"id":      "E1",
+		"day":     graphTemporal{storage: storageDate, t: at},
+		"local":   graphTemporal{storage: storageLocalDateTime, t: at},
+		"at":      at,
+		"timeout": 90 * time.Second,
+	}
+	if !reflect.DeepEqual(props, expected) {
+		t.Errorf("expected %v, got %v", expected, props)
+	}
+
+	type badEvent struct {
+		Name string `neo4j:"name=name,type=date"`
+	}
+	if _, err := structToProperties(badEvent{Name: "x"}); err == nil {
+		t.Errorf("expected error for type=date on a string field")
+	}
+
+	type badTagEvent struct {
+		ID   string `neo4j:"name=id,primary,label=BadEvent"`
+		Name string `neo4j:"name=name,type=date"`
+	}
+	driver := &fakeDriver{results: func(string) []*record { return nil }}
+	m := newModel(&Client{driver: driver, config: &Config{}}, &badTagEvent{})
+	if err := m.CreateBatch([]badTagEvent{{ID: "E1", Name: "x"}}); err == nil {
+		t.Errorf("expected CreateBatch to fail for an invalid type tag")
+	}
+	if err := m.MergeBatch([]badTagEvent{{ID: "E1", Name: "x"}}); err == nil {
+		t.Errorf("expected MergeBatch to fail for an invalid type tag")
+	}
+	if len(driver.queries) != 0 {
+		t.Errorf("expected no queries to be sent, got %q", driver.queries)
+	}
+}
+
+func TestTemporalMapping(t *testing.T) {
+	m := newModel(&Client{config: &Config{}}, &temporalTestEvent{})
+	at := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	var event temporalTestEvent
+	err := m.mapToStruct(map[string]interface{}{
+		"day":      at,
+		"at":       at,
+		"timeout":  durationOf(0, 1, 30, 0),
+		"deadline": int64(time.Minute),
+	}, &event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !event.Day.Equal(at) || event.At == nil || !event.At.Equal(at) {
+		t.Errorf("unexpected times: %v, %v", event.Day, event.At)
+	}
+	if event.Timeout != 24*time.Hour+30*time.Second || event.Deadline == nil || *event.Deadline != time.Minute {
+		t.Errorf("unexpected durations: %v, %v", event.Timeout, event.Deadline)
+	}
+
+	if seconds, nanos := splitDuration(-1500 * time.Millisecond); seconds != -2 || nanos != 500000000 {
+		t.Errorf("unexpected split: %d, %d", seconds, nanos)
+	}
+
+	// 条件和游标中的时间值按字段的存储类型转换
+	m.Where(Gte("Day", at), Eq("Timeout", int64(time.Second)))
+	if v := m.params["day_0"]; v != (graphTemporal{storage: storageDate, t: at}) {
+		t.Errorf("expected date parameter, got %#v", v)
+	}
+	if v := m.params["timeout_1"]; v != time.Second {
+		t.Errorf("expected duration parameter, got %#v", v)
+	}
+	v, err := m.propertyValue("at", at.Format(time.RFC3339Nano))
+	if err != nil || !reflect.DeepEqual(v, at) {
+		t.Errorf("expected cursor string to be parsed, got %#v, %v", v, err)
+	}
+}
@@ -11,23 +11,44 @@ const (
 	tagName      = "neo4j"
 	tagPrimary   = "primary"
 	tagTable     = "table"
+	tagLabel     = "label" // table 的别名
 	tagGenerated = "generated"
 	tagkey       = "name"
+	tagType      = "type" // 时间字段的存储类型：date、datetime、localdatetime
+
+	// 关系字段标签，如 `neo4j:"rel=FRIENDS,direction=both,merge=true"`
+	tagRel       = "rel"
+	tagDirection = "direction"
+	tagMerge     = "merge"
+
+	// 关系实体的起止节点字段，也可直接命名为 StartNode/EndNode
+	tagStart = "start"
+	tagEnd   = "end"
 )
 
+// 关系方向
+const (
+	Outgoing = "outgoing"
+	Incoming = "incoming"
+	Both     = "both"
+)
+
+// parseTag 解析标签，选项之间用 , 或 ; 分隔，键值之间用 = 或 : 分隔，
+// 没有值的选项（如 primary）记为空字符串
 func parseTag(tag string) map[string]string {
 	result := make(map[string]string)
-	parts := strings.Split(tag, ",")
+	parts := strings.FieldsFunc(tag, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
 	for _, part := range parts {
-		kv := strings.Split(part, "=")
-		if len(kv) >= 2 {
-			key := strings.TrimSpace(kv[0])
-			value := strings.TrimSpace(strings.Join(kv[1:], ":"))
+		if i := strings.IndexAny(part, "=:"); i >= 0 {
+			key := strings.TrimSpace(part[:i])
+			value := strings.TrimSpace(part[i+1:])
 			result[key] = value
+			continue
 		}
 
-		if len(kv) == 1 && kv[0] == tagPrimary {
-			key := strings.TrimSpace(kv[0])
+		if key := strings.TrimSpace(part); key != "" {
 			result[key] = ""
 		}
 	}
@@ -47,6 +68,7 @@ func structToProperties(v interface{}) (map[string]interface{}, error) {
 
 	props := make(map[string]interface{})
 	rt := rv.Type()
+	entity := isRelationshipEntity(rt)
 
 	for i := 0; i < rt.NumField(); i++ {
 		field := rt.Field(i)
@@ -59,21 +81,41 @@ func structToProperties(v interface{}) (map[string]interface{}, error) {
 		if _, ok := tags[tagGenerated]; ok {
 			continue
 		}
+		// 关系字段由 objectGraph 单独处理，关系实体的起止节点不是属性
+		if entity {
+			if isStartField(field, tags) || isEndField(field, tags) {
+				continue
+			}
+		} else if _, ok := tags[tagRel]; ok {
+			continue
+		}
 
 		propName := field.Name
-		if name, ok := tags["name"]; ok {
+		if name, ok := tags[tagkey]; ok && name != "" {
 			propName = name
 		}
 
 		fieldValue := rv.Field(i)
 		if !isZeroValue(fieldValue) {
-			props[propName] = fieldValue.Interface()
+			value, err := toPropertyValue(fieldValue.Interface(), tags[tagType])
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			props[propName] = value
 		}
-
 	}
 	return props, nil
 }
 
+// isNil 判断接口值是否为nil，包括带类型的nil指针
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
 // isZeroValue 判断是否为零值
 func isZeroValue(v reflect.Value) bool {
 	switch v.Kind() {
@@ -1,14 +1,67 @@
 package neo4jorm
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
 	"time"
-
-	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
 )
 
+// runWrite 在写事务中执行work，模型绑定了用户事务时直接在该事务中执行，
+// 由调用方负责提交或回滚
+func (m *Model) runWrite(work func(tx graphTx) error, config *txConfig) error {
+	if m.tx != nil {
+		return work(m.tx.tx)
+	}
+
+	ctx := m.context()
+	session := m.client.newSession(ctx)
+	defer session.close(context.Background())
+
+	return session.executeWrite(ctx, work, config)
+}
+
+// runAndConsume 在事务中执行语句并丢弃结果
+func runAndConsume(ctx context.Context, tx graphTx, query string, params map[string]interface{}) error {
+	result, err := tx.run(ctx, query, params)
+	if err != nil {
+		return err
+	}
+	return result.consume(ctx)
+}
+
+// execGraph 执行节点写入语句，模型声明了关系字段时在同一事务中一并保存关联节点和关系。
+// mergeRels为true时（MergeBatch、Update）关系始终使用MERGE，否则按字段的 merge 标签
+func (m *Model) execGraph(query string, params map[string]interface{}, nodesValue reflect.Value, config *txConfig, mergeRels bool) error {
+	if len(m.relFields) == 0 {
+		return m.exec(query, params, config)
+	}
+
+	graph, err := m.collectGraph(nodesValue, mergeRels)
+	if err != nil {
+		return err
+	}
+	ctx := m.context()
+	return m.runWrite(func(tx graphTx) error {
+		if err := runAndConsume(ctx, tx, query, params); err != nil {
+			return err
+		}
+		return graph.save(ctx, tx)
+	}, config)
+}
+
+// exec 执行单条写语句，未绑定用户事务时通过驱动的 executeQuery 自动重试执行
+func (m *Model) exec(query string, params map[string]interface{}, config *txConfig) error {
+	ctx := m.context()
+	if m.tx != nil {
+		return runAndConsume(ctx, m.tx.tx, query, params)
+	}
+
+	_, err := m.client.driver.executeQuery(ctx, m.client.config.Database, query, params, true, config)
+	return err
+}
+
 func (m *Model) CreateOne(node interface{}) error {
 	// 将单个节点包装成切片调用MergeBatch
 	nodes := []interface{}{node}
@@ -17,29 +70,42 @@ func (m *Model) CreateOne(node interface{}) error {
 
 // 批量创建节点，需自行创建唯一约束
 func (m *Model) CreateBatch(nodes interface{}) error {
+	if err := m.takeErr(); err != nil {
+		return err
+	}
 	// 添加类型验证
 	nodesValue := reflect.ValueOf(nodes)
 	if nodesValue.Kind() != reflect.Slice && nodesValue.Kind() != reflect.Array {
 		return fmt.Errorf("%s: expected slice/array, got %T", ErrInvalidModel, nodes)
 	}
 
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
-
-	_, err := session.WriteTransaction(func(tx neo4j.Transaction) (interface{}, error) {
-		result, err := tx.Run(buildCreateBatchQuery(m, nodesValue))
+	if m.isRelationship() {
+		query, params, err := buildRelationEntityQuery(m, nodesValue, "CREATE", "SET n += rel.props")
 		if err != nil {
-			return nil, fmt.Errorf("create batch failed: %w", err)
+			return err
+		}
+		if err := m.exec(query, params, &txConfig{timeout: 30 * time.Second}); err != nil {
+			return fmt.Errorf("create batch failed: %w", err)
 		}
-		return result.Consume()
-	}, neo4j.WithTxTimeout(30*time.Second))
+		return nil
+	}
 
-	return err
+	query, params, err := buildCreateBatchQuery(m, nodesValue)
+	if err != nil {
+		return fmt.Errorf("create batch failed: %w", err)
+	}
+	if err := m.execGraph(query, params, nodesValue, &txConfig{timeout: 30 * time.Second}, false); err != nil {
+		return fmt.Errorf("create batch failed: %w", err)
+	}
+	return nil
 }
 
-func buildCreateBatchQuery(m *Model, nodesValue reflect.Value) (string, map[string]interface{}) {
+// CreateBatchCtx 使用指定上下文批量创建节点
+func (m *Model) CreateBatchCtx(ctx context.Context, nodes interface{}) error {
+	return m.withCtx(ctx, func() error { return m.CreateBatch(nodes) })
+}
+
+func buildCreateBatchQuery(m *Model, nodesValue reflect.Value) (string, map[string]interface{}, error) {
 	var sb strings.Builder
 	sb.WriteString("UNWIND $nodes AS node ")
 	sb.WriteString("CREATE (n")
@@ -60,18 +126,33 @@ func buildCreateBatchQuery(m *Model, nodesValue reflect.Value) (string, map[stri
 	processed := make([]map[string]interface{}, 0, nodesValue.Len())
 	for i := 0; i < nodesValue.Len(); i++ {
 		node := nodesValue.Index(i).Interface()
-		props, _ := structToProperties(node)
+		props, err := structToProperties(node)
+		if err != nil {
+			return "", nil, err
+		}
 		processed = append(processed, map[string]interface{}{"props": props})
 	}
 	params := map[string]interface{}{"nodes": processed}
 	if m.debug {
 		fmt.Println(sb.String(), params)
 	}
-	return sb.String(), params
+	return sb.String(), params, nil
 }
 
 // 更新节点
 func (m *Model) Update(node interface{}) error {
+	if err := m.takeErr(); err != nil {
+		return err
+	}
+	// 关系实体按起止节点（及关系主键）定位后更新属性
+	if m.isRelationship() {
+		query, params, err := buildRelationEntityQuery(m, reflect.ValueOf([]interface{}{node}), "MATCH", "SET n += rel.props")
+		if err != nil {
+			return err
+		}
+		return m.exec(query, params, nil)
+	}
+
 	props, err := structToProperties(node)
 	if err != nil {
 		return err
@@ -93,19 +174,7 @@ func (m *Model) Update(node interface{}) error {
 		fmt.Printf("Executing Update:\n%s\nWith params: %+v\n", query, params)
 	}
 
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
-
-	_, err = session.WriteTransaction(func(tx neo4j.Transaction) (interface{}, error) {
-		result, err := tx.Run(query, params)
-		if err != nil {
-			return nil, err
-		}
-		return result.Consume()
-	})
-	return err
+	return m.execGraph(query, params, reflect.ValueOf([]interface{}{node}), nil, true)
 }
 
 // MergeOne 合并单个节点（存在则更新，不存在则创建）
@@ -117,30 +186,43 @@ func (m *Model) MergeOne(node interface{}) error {
 
 // MergeOne 批量合并多个节点（存在则更新，不存在则创建）
 func (m *Model) MergeBatch(nodes interface{}) error {
-
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
-
+	if err := m.takeErr(); err != nil {
+		return err
+	}
 	// 验证输入类型
 	nodesValue := reflect.ValueOf(nodes)
 	if nodesValue.Kind() != reflect.Slice && nodesValue.Kind() != reflect.Array {
 		return fmt.Errorf("%s: expected slice, got %T", ErrInvalidModel, nodes)
 	}
 
-	_, err := session.WriteTransaction(func(tx neo4j.Transaction) (interface{}, error) {
-		result, err := tx.Run(buildMergeQuery(m, nodesValue))
+	if m.isRelationship() {
+		query, params, err := buildRelationEntityQuery(m, nodesValue, "MERGE", "SET n += rel.props")
 		if err != nil {
-			return nil, fmt.Errorf("merge failed: %w", err)
+			return err
 		}
-		return result.Consume()
-	})
-	return err
+		if err := m.exec(query, params, nil); err != nil {
+			return fmt.Errorf("merge failed: %w", err)
+		}
+		return nil
+	}
+
+	query, params, err := buildMergeQuery(m, nodesValue)
+	if err != nil {
+		return fmt.Errorf("merge failed: %w", err)
+	}
+	if err := m.execGraph(query, params, nodesValue, nil, true); err != nil {
+		return fmt.Errorf("merge failed: %w", err)
+	}
+	return nil
+}
+
+// MergeBatchCtx 使用指定上下文批量合并节点
+func (m *Model) MergeBatchCtx(ctx context.Context, nodes interface{}) error {
+	return m.withCtx(ctx, func() error { return m.MergeBatch(nodes) })
 }
 
 // buildMergeQuery 构建合并查询（包含节点和关系）
-func buildMergeQuery(m *Model, nodesValue reflect.Value) (string, map[string]interface{}) {
+func buildMergeQuery(m *Model, nodesValue reflect.Value) (string, map[string]interface{}, error) {
 	var sb strings.Builder
 	params := make(map[string]interface{})
 
@@ -160,8 +242,7 @@ func buildMergeQuery(m *Model, nodesValue reflect.Value) (string, map[string]int
 		node := nodesValue.Index(i).Interface()
 		props, err := structToProperties(node)
 		if err != nil {
-			fmt.Println("structToProperties error: ", err)
-			return "", nil
+			return "", nil, err
 		}
 		processedNodes = append(processedNodes, map[string]interface{}{
 			"props": props,
@@ -173,7 +254,7 @@ func buildMergeQuery(m *Model, nodesValue reflect.Value) (string, map[string]int
 	if m.debug {
 		fmt.Printf("Executing Merge:\n%s\nWith params: %+v\n", query, params)
 	}
-	return sb.String(), params
+	return sb.String(), params, nil
 }
 
 // DeleteOne 删除一个节点
@@ -186,25 +267,35 @@ func (m *Model) DeleteOne(node interface{}) error {
 
 // DeleteBatch 批量删除节点（包含节点和关系）
 func (m *Model) DeleteBatch(nodes interface{}) error {
+	if err := m.takeErr(); err != nil {
+		return err
+	}
 	nodesValue := reflect.ValueOf(nodes)
 	if nodesValue.Kind() != reflect.Slice && nodesValue.Kind() != reflect.Array {
 		return fmt.Errorf("%s: expected slice/array, got %T", ErrInvalidModel, nodes)
 	}
 
-	session := m.client.driver.NewSession(neo4j.SessionConfig{
-		DatabaseName: m.client.config.Database,
-	})
-	defer session.Close()
-
-	_, err := session.WriteTransaction(func(tx neo4j.Transaction) (interface{}, error) {
-		query, params := buildDeleteQuery(m, nodesValue)
-		result, err := tx.Run(query, params)
+	if m.isRelationship() {
+		query, params, err := buildRelationEntityQuery(m, nodesValue, "MATCH", "DELETE n")
 		if err != nil {
-			return nil, fmt.Errorf("delete failed: %w", err)
+			return err
 		}
-		return result.Consume()
-	})
-	return err
+		if err := m.exec(query, params, nil); err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+		return nil
+	}
+
+	query, params := buildDeleteQuery(m, nodesValue)
+	if err := m.exec(query, params, nil); err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteBatchCtx 使用指定上下文批量删除节点
+func (m *Model) DeleteBatchCtx(ctx context.Context, nodes interface{}) error {
+	return m.withCtx(ctx, func() error { return m.DeleteBatch(nodes) })
 }
 
 func buildDeleteQuery(m *Model, nodesValue reflect.Value) (string, map[string]interface{}) {